@@ -1,124 +1,95 @@
 package main
 
 import (
-	"bufio"
+	"2024/parallel"
+	"2024/solution"
+	"2024/util"
+	"context"
 	"fmt"
+	"log/slog"
 	"math"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
-	"sync"
 )
 
 // Day 1 Solutions to Advent of Code 2024
 // Part 1 was to sort the two columns in ascending order, then sum the difference of each row
 // Part 2 was to create a frequency map out of the right column, then using the left column values, search the frequency map for value then multiple left_value * frequency. If left value is not present in map, then set to 0.
 
-// Wrote this using goroutines to practice writing concurrent go code, it is very overkill for this problem
+// Wrote this using goroutines to practice writing concurrent go code, it is very overkill for this problem.
+// The goroutine per row was later swapped for the shared worker pool in the parallel package
 
 func main() {
-	rawData := readInData("Day1/part1Data.txt")
-	leftValues, rightValues := convertInputData(rawData)
-	part1(leftValues, rightValues)
-	part2(leftValues, rightValues)
-}
-
-// readInData takes a file and reads each line in
-func readInData(filename string) []string {
-	fmt.Println("Reading file ", filename)
-	data, _ := os.Open(filename)
-	scanner := bufio.NewScanner(data)
-	scanner.Split(bufio.ScanLines)
-	var fileLines []string
-	for scanner.Scan() {
-		fileLines = append(fileLines, scanner.Text())
+	rawData := util.ReadInput(util.Parameter())
+	leftValues, rightValues, err := convertInputData(rawData)
+	if err != nil {
+		slog.Error("Unable to read the location IDs", "err", err)
+		os.Exit(1)
 	}
-	data.Close()
-	return fileLines
+	sol := solution.New(1)
+	sol.Part(1, func() any { return part1(leftValues, rightValues) })
+	sol.Part(2, func() any { return part2(leftValues, rightValues) })
 }
 
-// convertInputData takes the raw data that was read into the file and turns it into a usable format for the problem
-func convertInputData(input_data []string) (leftValues []int, rightValues []int) {
-	fmt.Println("Converting raw Data")
-	for _, line := range input_data {
+// convertInputData takes the raw data that was read into the file and turns it into a usable format for the problem.
+// Every line has to hold exactly two numbers
+func convertInputData(input_data []string) (leftValues []int, rightValues []int, err error) {
+	slog.Debug("Converting raw Data")
+	for i, line := range input_data {
 		fields := strings.Fields(line)
-		left, _ := strconv.Atoi(fields[0])
-		right, _ := strconv.Atoi(fields[1])
+		if len(fields) != 2 {
+			return nil, nil, fmt.Errorf("line %d has %d values, expected 2", i+1, len(fields))
+		}
+		left, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		right, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
 
 		leftValues = append(leftValues, left)
 		rightValues = append(rightValues, right)
 	}
-	return
+	return leftValues, rightValues, nil
 }
 
 // part1 Had to sort the two slices in ascending order then find the difference values at same indices and then sum the differences
 // Example: [1,2,3] [2.3.4] -> 1 + 1 + 1 -> 3
 // I wrote it concurrently to get practice writing concurrent go code (Very overkill)
-func part1(leftValues []int, rightValues []int) {
+func part1(leftValues []int, rightValues []int) int {
 	sort.Ints(leftValues)
 	sort.Ints(rightValues)
-	result := make(chan int, len(leftValues))
-	var wg sync.WaitGroup
 
-	for index, _ := range leftValues {
-		wg.Add(1)
-		go func(left, right int, result chan<- int, wg *sync.WaitGroup) {
-			defer wg.Done()
-			result <- int(math.Abs(float64(left - right)))
-		}(leftValues[index], rightValues[index], result, &wg)
+	pairs := make([][2]int, len(leftValues))
+	for index := range leftValues {
+		pairs[index] = [2]int{leftValues[index], rightValues[index]}
 	}
 
-	go func() {
-		wg.Wait()
-		close(result)
-	}()
-
-	answer := 0
-	for resultValue := range result {
-		answer += resultValue
-	}
+	answer, _ := parallel.MapReduce(context.Background(), pairs, func(_ context.Context, pair [2]int) (int, error) {
+		return int(math.Abs(float64(pair[0] - pair[1]))), nil
+	}, 0, parallel.Sum[int])
 
-	fmt.Println("part1 answer is", answer)
+	return answer
 }
 
 // part2 multiple values in the leftValue slice by their frequency in the second map
 // Example [1, 2, 3] [1,1,2] -> (1 * 2) + (2 * 1) + (3 * 0) -> 3
 // Using a frequency map created out of the right values, iterate through left values and calculate "similarity score"
 // with the frequency map
-func part2(leftValues []int, rightValues []int) {
+func part2(leftValues []int, rightValues []int) int {
 	frequencyMap := make(map[int]int)
 	populateFrequencyMap(frequencyMap, rightValues)
 
-	result := make(chan int, len(leftValues))
-	var wg sync.WaitGroup
-
-	for _, value := range leftValues {
-		wg.Add(1)
-
-		go func(value int, result chan<- int, wg *sync.WaitGroup) {
-			defer wg.Done()
-
-			toAdd := 0
-			if frequency, ok := frequencyMap[value]; ok {
-				toAdd = value * frequency
-			}
-			result <- toAdd
-
-		}(value, result, &wg)
-	}
-
-	go func() {
-		wg.Wait()
-		close(result)
-	}()
-
-	ans := 0
-	for resultValue := range result {
-		ans += resultValue
-	}
+	// the frequency map is only read from here on, so the workers can share it
+	ans, _ := parallel.MapReduce(context.Background(), leftValues, func(_ context.Context, value int) (int, error) {
+		return value * frequencyMap[value], nil
+	}, 0, parallel.Sum[int])
 
-	fmt.Println("part2 answer is", ans)
+	return ans
 }
 
 // populateFrequencyMap used to create a frequency map out of a int slice
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(locations(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(locations(b, input))
+	}
+}
+
+func locations(tb testing.TB, input []string) ([]int, []int) {
+	left, right, err := convertInputData(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return left, right
+}
+
+func FuzzConvertInputData(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		left, right, err := convertInputData(lines)
+		if err == nil && (len(left) != len(lines) || len(right) != len(lines)) {
+			t.Errorf("Expected a pair of numbers per line, got %d and %d for %d lines", len(left), len(right), len(lines))
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		left, right := locations(t, input)
+		if example.Part == 2 {
+			return part2(left, right)
+		}
+		return part1(left, right)
+	})
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"2024/Day10/trail"
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"2024/util"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(topography(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(topography(b, input))
+	}
+}
+
+func topography(tb testing.TB, input []string) *trail.Map {
+	grid, err := util.DigitGrid(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	m, err := trail.NewMap(grid, trail.DefaultRules)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return m
+}
+
+func FuzzNewMap(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		grid, err := util.DigitGrid(lines)
+		if err != nil {
+			return
+		}
+		m, err := trail.NewMap(grid, trail.DefaultRules)
+		if err != nil {
+			return
+		}
+		// every summit a trailhead reaches is at the end of at least one of its trails
+		for _, head := range m.Trailheads() {
+			if score, rating := m.Score(head), m.Rating(head); score > rating {
+				t.Fatalf("Expected trailhead %v to have a score %d <= its rating %d", head, score, rating)
+			}
+		}
+		if score, rating := part1(m), part2(m); score > rating {
+			t.Errorf("Expected the total score %d <= the total rating %d", score, rating)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		if example.Part == 2 {
+			return part2(topography(t, input))
+		}
+		return part1(topography(t, input))
+	})
+}
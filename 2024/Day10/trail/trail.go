@@ -0,0 +1,195 @@
+package trail
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+var (
+	orthogonal = [][2]int{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+	diagonal   = [][2]int{{-1, -1}, {-1, 1}, {1, 1}, {1, -1}}
+)
+
+// Rules configure what counts as a hiking trail
+//   - MinDelta, MaxDelta: how much the height has to go up with every step
+//   - Diagonal: whether diagonal steps are allowed
+//   - Start: height of a trailhead
+//   - Peak: height of a peak, a trail ends as soon as it reaches one
+type Rules struct {
+	MinDelta, MaxDelta int
+	Diagonal           bool
+	Start, Peak        int
+}
+
+// DefaultRules are the rules of the puzzle, trails go from 0 to 9 one step up at a time, never diagonally
+var DefaultRules = Rules{MinDelta: 1, MaxDelta: 1, Start: 0, Peak: 9}
+
+// Map is a topographic map along with the score and rating of every cell, computed once with dynamic programming.
+//
+// Since every step has to go up, cells are processed from the highest to the lowest and each one only needs the
+// results of its higher neighbors: the rating of a cell is the sum of its neighbors' ratings and the peaks it can
+// reach are the union of its neighbors' peaks
+type Map struct {
+	grid       [][]int
+	rules      Rules
+	directions [][2]int
+	rating     [][]int
+	// peaks[r][c] is a bitset of the peaks reachable from the cell, indexed by the order peaks appear in the grid
+	peaks [][][]uint64
+}
+
+// NewMap creates a Map and computes the score and rating of every cell
+func NewMap(grid [][]int, rules Rules) (*Map, error) {
+	if rules.MinDelta < 1 || rules.MaxDelta < rules.MinDelta {
+		return nil, fmt.Errorf("invalid height delta %d..%d, trails have to go up with every step", rules.MinDelta, rules.MaxDelta)
+	}
+	for r, row := range grid {
+		if len(row) != len(grid[0]) {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", r, len(row), len(grid[0]))
+		}
+	}
+
+	m := &Map{grid: grid, rules: rules, directions: orthogonal}
+	if rules.Diagonal {
+		m.directions = append(append([][2]int{}, orthogonal...), diagonal...)
+	}
+	m.compute()
+	return m, nil
+}
+
+// compute fills in the rating and reachable peaks of every cell, highest cells first
+func (m *Map) compute() {
+	cells := make([][2]int, 0)
+	peakIndex := make(map[[2]int]int)
+	for r, row := range m.grid {
+		for c, height := range row {
+			cells = append(cells, [2]int{r, c})
+			if height == m.rules.Peak {
+				peakIndex[[2]int{r, c}] = len(peakIndex)
+			}
+		}
+	}
+	sort.SliceStable(cells, func(i, j int) bool {
+		return m.height(cells[i]) > m.height(cells[j])
+	})
+
+	words := (len(peakIndex) + 63) / 64
+	m.rating = make([][]int, len(m.grid))
+	m.peaks = make([][][]uint64, len(m.grid))
+	for r := range m.grid {
+		m.rating[r] = make([]int, len(m.grid[r]))
+		m.peaks[r] = make([][]uint64, len(m.grid[r]))
+	}
+
+	for _, cell := range cells {
+		r, c := cell[0], cell[1]
+		m.peaks[r][c] = make([]uint64, words)
+
+		if m.grid[r][c] == m.rules.Peak {
+			index := peakIndex[cell]
+			m.rating[r][c] = 1
+			m.peaks[r][c][index/64] |= 1 << (index % 64)
+			continue
+		}
+
+		for _, next := range m.next(cell) {
+			m.rating[r][c] += m.rating[next[0]][next[1]]
+			for w, word := range m.peaks[next[0]][next[1]] {
+				m.peaks[r][c][w] |= word
+			}
+		}
+	}
+}
+
+// next returns the neighbors of the cell the trail can step to
+func (m *Map) next(cell [2]int) [][2]int {
+	neighbors := make([][2]int, 0, len(m.directions))
+	height := m.height(cell)
+	for _, dir := range m.directions {
+		nr, nc := cell[0]+dir[0], cell[1]+dir[1]
+		if nr < 0 || nr >= len(m.grid) || nc < 0 || nc >= len(m.grid[nr]) {
+			continue
+		}
+		if delta := m.grid[nr][nc] - height; delta >= m.rules.MinDelta && delta <= m.rules.MaxDelta {
+			neighbors = append(neighbors, [2]int{nr, nc})
+		}
+	}
+	return neighbors
+}
+
+func (m *Map) height(cell [2]int) int {
+	return m.grid[cell[0]][cell[1]]
+}
+
+// Trailheads returns every cell at the start height, in reading order
+func (m *Map) Trailheads() [][2]int {
+	heads := make([][2]int, 0)
+	for r, row := range m.grid {
+		for c, height := range row {
+			if height == m.rules.Start {
+				heads = append(heads, [2]int{r, c})
+			}
+		}
+	}
+	return heads
+}
+
+// Score is the number of distinct peaks reachable from the cell
+func (m *Map) Score(cell [2]int) int {
+	count := 0
+	for _, word := range m.peaks[cell[0]][cell[1]] {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// Rating is the number of distinct trails from the cell to any peak
+func (m *Map) Rating(cell [2]int) int {
+	return m.rating[cell[0]][cell[1]]
+}
+
+// TotalScore sums the score of every trailhead
+func (m *Map) TotalScore() int {
+	total := 0
+	for _, head := range m.Trailheads() {
+		total += m.Score(head)
+	}
+	return total
+}
+
+// TotalRating sums the rating of every trailhead
+func (m *Map) TotalRating() int {
+	total := 0
+	for _, head := range m.Trailheads() {
+		total += m.Rating(head)
+	}
+	return total
+}
+
+// Trails lists up to k trails starting from the cell, in the order of a depth first search that tries the
+// directions clockwise starting from up. Dead ends are never explored since their rating is 0
+func (m *Map) Trails(cell [2]int, k int) [][][2]int {
+	trails := make([][][2]int, 0)
+	path := make([][2]int, 0)
+
+	var walk func(current [2]int)
+	walk = func(current [2]int) {
+		if len(trails) >= k || m.Rating(current) == 0 {
+			return
+		}
+		path = append(path, current)
+		defer func() { path = path[:len(path)-1] }()
+
+		if m.height(current) == m.rules.Peak {
+			trails = append(trails, append([][2]int{}, path...))
+			return
+		}
+		for _, next := range m.next(current) {
+			walk(next)
+		}
+	}
+
+	walk(cell)
+	return trails
+}
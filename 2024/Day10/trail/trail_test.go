@@ -0,0 +1,86 @@
+package trail
+
+import (
+	"testing"
+)
+
+var example = [][]int{
+	{8, 9, 0, 1, 0, 1, 2, 3},
+	{7, 8, 1, 2, 1, 8, 7, 4},
+	{8, 7, 4, 3, 0, 9, 6, 5},
+	{9, 6, 5, 4, 9, 8, 7, 4},
+	{4, 5, 6, 7, 8, 9, 0, 3},
+	{3, 2, 0, 1, 9, 0, 1, 2},
+	{0, 1, 3, 2, 9, 8, 0, 1},
+	{1, 0, 4, 5, 6, 7, 3, 2},
+}
+
+func TestMap_Example(t *testing.T) {
+	m, err := NewMap(example, DefaultRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := m.TotalScore(); got != 36 {
+		t.Errorf("Expected total score 36, got %d", got)
+	}
+	if got := m.TotalRating(); got != 81 {
+		t.Errorf("Expected total rating 81, got %d", got)
+	}
+	if got := m.Score([2]int{0, 2}); got != 5 {
+		t.Errorf("Expected first trailhead to score 5, got %d", got)
+	}
+	if got := m.Rating([2]int{0, 2}); got != 20 {
+		t.Errorf("Expected first trailhead to be rated 20, got %d", got)
+	}
+}
+
+func TestMap_Trails(t *testing.T) {
+	m, err := NewMap(example, DefaultRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head := [2]int{0, 2}
+	all := m.Trails(head, 100)
+	if len(all) != m.Rating(head) {
+		t.Errorf("Expected %d trails, got %d", m.Rating(head), len(all))
+	}
+	for _, trail := range all {
+		if len(trail) != 10 || trail[0] != head || example[trail[9][0]][trail[9][1]] != 9 {
+			t.Errorf("Unexpected trail %v", trail)
+		}
+	}
+
+	if got := m.Trails(head, 3); len(got) != 3 {
+		t.Errorf("Expected 3 trails, got %d", len(got))
+	}
+}
+
+func TestMap_Rules(t *testing.T) {
+	grid := [][]int{
+		{0, 9, 9},
+		{9, 1, 9},
+		{9, 9, 2},
+	}
+
+	straight, err := NewMap(grid, Rules{MinDelta: 1, MaxDelta: 1, Start: 0, Peak: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := straight.TotalRating(); got != 0 {
+		t.Errorf("Expected no trails without diagonals, got %d", got)
+	}
+
+	diagonal, err := NewMap(grid, Rules{MinDelta: 1, MaxDelta: 2, Diagonal: true, Start: 0, Peak: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := diagonal.TotalRating(); got != 1 {
+		t.Errorf("Expected a single diagonal trail, got %d", got)
+	}
+
+	if _, err := NewMap(grid, Rules{MinDelta: 0, MaxDelta: 1}); err == nil {
+		t.Error("Expected error for a delta that allows flat steps")
+	}
+}
@@ -1,8 +1,13 @@
 package main
 
 import (
+	"2024/parallel"
+	"2024/solution"
 	"2024/util"
+	"context"
 	"fmt"
+	"log/slog"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -12,109 +17,58 @@ const (
 	part1Rounds = 25
 	part2Rounds = 75
 	ruleThree   = 2024
-	workers     = 10
 )
 
-// Task represents works
-//   - stoneNum: starting number on stone
-//   - rounds: number of rounds that need to be computed
-type Task struct {
-	stoneNum int
-	rounds   int
-}
-
-// Result represents finished work
-//   - stoneNum: original stone number
-//   - rounds: number of rounds that were computed
-//   - count: number of stones created from original stone and rounds worked
-type Result struct {
-	stoneNum int
-	rounds   int
-	count    int
-}
-
 func main() {
-	input := convertInt(util.ReadInput(util.Parameter()))
-	part1(input)
-	part2(input)
+	input, err := convertInt(util.ReadInput(util.Parameter()))
+	if err != nil {
+		slog.Error("Unable to read the stones", "err", err)
+		os.Exit(1)
+	}
+	sol := solution.New(11)
+	sol.Part(1, func() any { return part1(input) })
+	sol.Part(2, func() any { return part2(input) })
 }
 
-func convertInt(input []string) []int {
+// convertInt reads the numbers engraved on the stones, they are all on the first line separated by spaces.
+// The rules only work for numbers without a sign, so a negative number is an error
+func convertInt(input []string) ([]int, error) {
+	if len(input) == 0 || len(strings.Fields(input[0])) == 0 {
+		return nil, fmt.Errorf("there are no stones")
+	}
 	toReturn := make([]int, 0)
-	for _, number := range strings.Split(input[0], " ") {
-		toReturn = append(toReturn, util.ParseInt(number))
+	for _, engraving := range strings.Fields(input[0]) {
+		stone, err := strconv.Atoi(engraving)
+		if err != nil || stone < 0 {
+			return nil, fmt.Errorf("%q is not a number a stone can have", engraving)
+		}
+		toReturn = append(toReturn, stone)
 	}
-	return toReturn
+	return toReturn, nil
 }
 
-// part1 solves part 1, creates 10 workers, and creates the tasks out of input data
+// part1 solves part 1, spreading the stones over the worker pool
 // for each stone, part1Rounds are computed
-func part1(input []int) {
-	memo := make(map[string]int)
-	var mu sync.Mutex
-
-	// create channels to send tasks to workers, and then collect the results out of the works
-	tasks := make(chan Task)
-	results := make(chan Result, len(input))
-	var wg sync.WaitGroup
-
-	// add worker count to barrier then launch workers
-	wg.Add(workers)
-	for w := 0; w < workers; w++ {
-		go worker(tasks, results, memo, &wg, &mu, part1Rounds)
-	}
-
-	// create and send tasks to workers
-	for _, stone := range input {
-		tasks <- Task{stone, part1Rounds}
-	}
-	close(tasks)
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	// collect results
-	count := 0
-	for result := range results {
-		count += result.count
-	}
-
-	fmt.Println("(Part 1) Ans:", count)
+func part1(input []int) int {
+	return blink(input, part1Rounds)
 }
 
-// part2 solves part 2, creates 10 workers, and creates the tasks out of input data
+// part2 solves part 2, spreading the stones over the worker pool
 // for each stone, part2Rounds are computed
-func part2(input []int) {
+func part2(input []int) int {
+	return blink(input, part2Rounds)
+}
+
+// blink counts the stones every starting stone turns into after the given number of rounds, the workers share the memo
+// so a stone worked out for one starting stone is reused by all of them
+func blink(input []int, rounds int) int {
 	memo := make(map[string]int)
 	var mu sync.Mutex
 
-	tasks := make(chan Task)
-	results := make(chan Result, len(input))
-	var wg sync.WaitGroup
-
-	wg.Add(workers)
-	for w := 0; w < workers; w++ {
-		go worker(tasks, results, memo, &wg, &mu, part2Rounds)
-	}
-
-	for _, stone := range input {
-		tasks <- Task{stone, part2Rounds}
-	}
-	close(tasks)
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	count := 0
-	for result := range results {
-		count += result.count
-	}
-
-	fmt.Println("(Part 2) Ans:", count)
+	count, _ := parallel.MapReduce(context.Background(), input, func(_ context.Context, stone int) (int, error) {
+		return countStones(stone, rounds, memo, &mu), nil
+	}, 0, parallel.Sum[int])
+	return count
 }
 
 // countStones is the function that calculates the number of stones created a specific stone for an n number of rounds
@@ -161,13 +115,3 @@ func countStones(stone int, round int, memo map[string]int, mu *sync.Mutex) int
 
 	return result
 }
-
-// worker a function that is designed to complete a task, in this case work on a task
-// the workers are created on a goroutine, and the workers share the channel of tasks to work on
-func worker(tasks <-chan Task, results chan<- Result, memo map[string]int, wg *sync.WaitGroup, mu *sync.Mutex, rounds int) {
-	defer wg.Done()
-	for task := range tasks {
-		count := countStones(task.stoneNum, task.rounds, memo, mu)
-		results <- Result{stoneNum: task.stoneNum, rounds: rounds, count: count}
-	}
-}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"strings"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 11)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(stones(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 11)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(stones(b, input))
+	}
+}
+
+func stones(tb testing.TB, input []string) []int {
+	s, err := convertInt(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return s
+}
+
+func FuzzConvertInt(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		stones, err := convertInt(lines)
+		if err != nil {
+			return
+		}
+		if len(stones) != len(strings.Fields(lines[0])) {
+			t.Fatalf("Expected a stone per number on %q, got %v", lines[0], stones)
+		}
+		// a blink never takes a stone away and splits a stone into at most two
+		if count := blink(stones, 5); count < len(stones) || count > len(stones)<<5 {
+			t.Errorf("Expected between %d and %d stones after 5 blinks, got %d", len(stones), len(stones)<<5, count)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		if example.Part == 2 {
+			return part2(stones(t, input))
+		}
+		return part1(stones(t, input))
+	})
+}
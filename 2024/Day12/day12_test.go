@@ -0,0 +1,72 @@
+package main
+
+import (
+	"2024/Day12/regions"
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"2024/util"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 12)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(regions.Label(garden(b, input)))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 12)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(regions.Label(garden(b, input)))
+	}
+}
+
+func garden(tb testing.TB, input []string) *util.Grid[string] {
+	grid, err := util.GridFrom(util.TransformStringSliceInto2DMatrix(input))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return grid
+}
+
+func FuzzGridFrom(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		rows, err := util.CharGrid(lines)
+		if err != nil {
+			return
+		}
+		grid, err := util.GridFrom(rows)
+		if err != nil {
+			return
+		}
+		// the regions cover the garden, and every region is fenced by at least 4 straight sides
+		garden := regions.Label(grid)
+		area := 0
+		for _, region := range garden.Regions {
+			area += region.Area
+			if region.Sides < 4 || region.Sides > region.Perimeter {
+				t.Fatalf("Expected region %d to have between 4 and %d sides, got %d", region.ID, region.Perimeter, region.Sides)
+			}
+		}
+		if area != grid.Rows()*grid.Cols() {
+			t.Errorf("Expected the regions to cover %d cells, got %d", grid.Rows()*grid.Cols(), area)
+		}
+		if price, discount := part1(garden), part2(garden); discount > price {
+			t.Errorf("Expected the discount %d <= the price %d", discount, price)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		labeled := regions.Label(garden(t, input))
+		if example.Part == 2 {
+			return part2(labeled)
+		}
+		return part1(labeled)
+	})
+}
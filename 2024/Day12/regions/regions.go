@@ -0,0 +1,330 @@
+package regions
+
+import (
+	"2024/util"
+	"sort"
+)
+
+var orthogonal = [][2]int{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+
+// quadrants pairs every diagonal with the two orthogonal neighbors next to it, used to find corners
+var quadrants = [][3][2]int{
+	{{-1, -1}, {-1, 0}, {0, -1}},
+	{{-1, 1}, {-1, 0}, {0, 1}},
+	{{1, 1}, {1, 0}, {0, 1}},
+	{{1, -1}, {1, 0}, {0, -1}},
+}
+
+// outside is the node standing in for everything past the edge of the grid in the region graph
+const outside = 0
+
+// Bounds is the smallest rectangle holding every cell of a region, both ends are inclusive
+type Bounds struct {
+	MinRow, MinCol int
+	MaxRow, MaxCol int
+}
+
+// Hole is a pocket inside a region that is cut off from the edge of the grid
+//   - Regions: IDs of the regions directly inside the hole, regions nested deeper are enclosed by these
+//   - Area: number of cells in the hole, nested regions included
+type Hole struct {
+	Regions []int
+	Area    int
+}
+
+// Region is a connected group of cells holding the same value
+//   - ID: regions are numbered in the order their first cell shows up, row by row
+//   - Value: the value every cell of the region holds
+//   - Cells: every cell of the region, row by row
+//   - Area: number of cells
+//   - Perimeter: number of cell edges that border another region or the edge of the grid
+//   - Sides: number of straight fence sections around the region (holes included), equal to its number of corners
+//   - Bounds: bounding box of the region
+//   - Holes: pockets of other regions the region surrounds
+//   - Parent: ID of the innermost region that encloses this one, -1 if it isn't enclosed. A region is enclosed by
+//     another when every path from it to the edge of the grid crosses the other
+//   - Enclosed: IDs of the regions whose Parent is this region
+type Region[T comparable] struct {
+	ID        int
+	Value     T
+	Cells     [][2]int
+	Area      int
+	Perimeter int
+	Sides     int
+	Bounds    Bounds
+	Holes     []Hole
+	Parent    int
+	Enclosed  []int
+}
+
+// Labeling is every region of a grid along with the region each cell belongs to
+type Labeling[T comparable] struct {
+	Regions []Region[T]
+	labels  []int
+	grid    *util.Grid[T]
+}
+
+// Label splits the grid into regions.
+//
+// Cells are labeled in a single linear pass with union-find, joining every cell with its left and upper neighbor
+// when they hold the same value, so no flood fill (and no recursion) is needed however big a region gets.
+// A second pass over the labels measures every region. Enclosure comes from the graph of touching regions: a region
+// encloses another exactly when it dominates it, with the edge of the grid as the root, so the Parent of a region
+// is its immediate dominator
+func Label[T comparable](grid *util.Grid[T]) *Labeling[T] {
+	l := &Labeling[T]{Regions: make([]Region[T], 0), labels: make([]int, grid.Rows()*grid.Cols()), grid: grid}
+
+	sets := util.NewUnionFind(len(l.labels))
+	for r := 0; r < grid.Rows(); r++ {
+		for c := 0; c < grid.Cols(); c++ {
+			if c > 0 && grid.Get(r, c-1) == grid.Get(r, c) {
+				sets.Union(grid.Index(r, c-1), grid.Index(r, c))
+			}
+			if r > 0 && grid.Get(r-1, c) == grid.Get(r, c) {
+				sets.Union(grid.Index(r-1, c), grid.Index(r, c))
+			}
+		}
+	}
+
+	ids := make(map[int]int)
+	for r := 0; r < grid.Rows(); r++ {
+		for c := 0; c < grid.Cols(); c++ {
+			root := sets.Find(grid.Index(r, c))
+			id, ok := ids[root]
+			if !ok {
+				id = len(l.Regions)
+				ids[root] = id
+				l.Regions = append(l.Regions, Region[T]{
+					ID:       id,
+					Value:    grid.Get(r, c),
+					Cells:    make([][2]int, 0),
+					Bounds:   Bounds{MinRow: r, MinCol: c, MaxRow: r, MaxCol: c},
+					Holes:    make([]Hole, 0),
+					Parent:   -1,
+					Enclosed: make([]int, 0),
+				})
+			}
+			l.labels[grid.Index(r, c)] = id
+			l.Regions[id].Cells = append(l.Regions[id].Cells, [2]int{r, c})
+		}
+	}
+
+	l.measure()
+	l.nest()
+	return l
+}
+
+// At returns the ID of the region the cell belongs to, -1 if the cell is outside the grid
+func (l *Labeling[T]) At(row, col int) int {
+	if !l.grid.InBounds(row, col) {
+		return -1
+	}
+	return l.labels[l.grid.Index(row, col)]
+}
+
+// Encloses checks if inner lies anywhere inside outer, no matter how deeply nested
+func (l *Labeling[T]) Encloses(outer, inner int) bool {
+	for id := l.Regions[inner].Parent; id != -1; id = l.Regions[id].Parent {
+		if id == outer {
+			return true
+		}
+	}
+	return false
+}
+
+// measure works out the area, perimeter, sides and bounds of every region
+func (l *Labeling[T]) measure() {
+	for i := range l.Regions {
+		region := &l.Regions[i]
+		region.Area = len(region.Cells)
+
+		for _, cell := range region.Cells {
+			region.Bounds.MinRow = min(region.Bounds.MinRow, cell[0])
+			region.Bounds.MinCol = min(region.Bounds.MinCol, cell[1])
+			region.Bounds.MaxRow = max(region.Bounds.MaxRow, cell[0])
+			region.Bounds.MaxCol = max(region.Bounds.MaxCol, cell[1])
+
+			for _, d := range orthogonal {
+				if l.At(cell[0]+d[0], cell[1]+d[1]) != region.ID {
+					region.Perimeter++
+				}
+			}
+
+			// a corner is either two orthogonal neighbors outside the region, or two inside with the diagonal outside
+			for _, q := range quadrants {
+				diag := l.At(cell[0]+q[0][0], cell[1]+q[0][1]) == region.ID
+				first := l.At(cell[0]+q[1][0], cell[1]+q[1][1]) == region.ID
+				second := l.At(cell[0]+q[2][0], cell[1]+q[2][1]) == region.ID
+				if (!first && !second) || (first && second && !diag) {
+					region.Sides++
+				}
+			}
+		}
+	}
+}
+
+// nest builds the graph of touching regions and uses its dominator tree to fill in Parent, Enclosed and Holes.
+// Node 0 of the graph is the outside, region i is node i+1
+func (l *Labeling[T]) nest() {
+	if len(l.Regions) == 0 {
+		return
+	}
+
+	graph := l.adjacency()
+	order := reversePostorder(graph)
+	idom := dominators(graph, order)
+
+	for i := range l.Regions {
+		if parent := idom[i+1] - 1; parent != -1 {
+			l.Regions[i].Parent = parent
+			l.Regions[parent].Enclosed = append(l.Regions[parent].Enclosed, i)
+		}
+	}
+
+	// regions with the same parent that touch each other share a hole
+	sets := util.NewUnionFind(len(l.Regions))
+	for i := range l.Regions {
+		for _, node := range graph[i+1] {
+			if j := node - 1; j != -1 && l.Regions[i].Parent != -1 && l.Regions[i].Parent == l.Regions[j].Parent {
+				sets.Union(i, j)
+			}
+		}
+	}
+
+	// every region nested inside comes later in the dominator tree, so walking backwards totals whole subtrees
+	totals := make([]int, len(l.Regions))
+	for k := len(order) - 1; k >= 0; k-- {
+		if i := order[k] - 1; i != -1 {
+			totals[i] += l.Regions[i].Area
+			if parent := l.Regions[i].Parent; parent != -1 {
+				totals[parent] += totals[i]
+			}
+		}
+	}
+
+	for parent := range l.Regions {
+		holes := make(map[int]int)
+		for _, child := range l.Regions[parent].Enclosed {
+			root := sets.Find(child)
+			index, ok := holes[root]
+			if !ok {
+				index = len(l.Regions[parent].Holes)
+				holes[root] = index
+				l.Regions[parent].Holes = append(l.Regions[parent].Holes, Hole{Regions: make([]int, 0)})
+			}
+			hole := &l.Regions[parent].Holes[index]
+			hole.Regions = append(hole.Regions, child)
+			hole.Area += totals[child]
+		}
+	}
+}
+
+// adjacency lists the nodes touching every node of the region graph, sorted so the result doesn't depend on map order
+func (l *Labeling[T]) adjacency() [][]int {
+	edges := make([]map[int]bool, len(l.Regions)+1)
+	for i := range edges {
+		edges[i] = make(map[int]bool)
+	}
+
+	for r := 0; r < l.grid.Rows(); r++ {
+		for c := 0; c < l.grid.Cols(); c++ {
+			node := l.At(r, c) + 1
+			for _, d := range orthogonal {
+				if other := l.At(r+d[0], c+d[1]) + 1; other != node {
+					edges[node][other] = true
+					edges[other][node] = true
+				}
+			}
+		}
+	}
+
+	graph := make([][]int, len(edges))
+	for node, neighbors := range edges {
+		graph[node] = make([]int, 0, len(neighbors))
+		for other := range neighbors {
+			graph[node] = append(graph[node], other)
+		}
+		sort.Ints(graph[node])
+	}
+	return graph
+}
+
+// reversePostorder numbers the nodes reachable from the outside with an iterative depth first search
+func reversePostorder(graph [][]int) []int {
+	visited := make([]bool, len(graph))
+	order := make([]int, 0, len(graph))
+
+	// every stack entry is a node and the index of the next neighbor to look at
+	stack := [][2]int{{outside, 0}}
+	visited[outside] = true
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		node := top[0]
+		if top[1] == len(graph[node]) {
+			order = append(order, node)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := graph[node][top[1]]
+		top[1]++
+		if !visited[next] {
+			visited[next] = true
+			stack = append(stack, [2]int{next, 0})
+		}
+	}
+
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
+// dominators finds the immediate dominator of every node with the outside as the root, using the iterative algorithm
+// from Cooper, Harvey and Kennedy, visiting nodes in reverse postorder. The root is its own dominator
+func dominators(graph [][]int, order []int) []int {
+	position := make([]int, len(graph))
+	for i, node := range order {
+		position[node] = i
+	}
+
+	idom := make([]int, len(graph))
+	for i := range idom {
+		idom[i] = -1
+	}
+	idom[outside] = outside
+
+	intersect := func(a, b int) int {
+		for a != b {
+			for position[a] > position[b] {
+				a = idom[a]
+			}
+			for position[b] > position[a] {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, node := range order[1:] {
+			candidate := -1
+			for _, pred := range graph[node] {
+				if idom[pred] == -1 {
+					continue
+				}
+				if candidate == -1 {
+					candidate = pred
+				} else {
+					candidate = intersect(pred, candidate)
+				}
+			}
+			if candidate != idom[node] {
+				idom[node] = candidate
+				changed = true
+			}
+		}
+	}
+	return idom
+}
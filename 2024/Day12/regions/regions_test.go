@@ -0,0 +1,182 @@
+package regions
+
+import (
+	"2024/util"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func label(t *testing.T, rows ...string) *Labeling[string] {
+	t.Helper()
+	grid, err := util.GridFrom(util.TransformStringSliceInto2DMatrix(rows))
+	if err != nil {
+		t.Fatalf("Invalid grid: %v", err)
+	}
+	return Label(grid)
+}
+
+func prices(l *Labeling[string]) (int, int) {
+	cost, discount := 0, 0
+	for _, region := range l.Regions {
+		cost += region.Area * region.Perimeter
+		discount += region.Area * region.Sides
+	}
+	return cost, discount
+}
+
+func TestLabel_Examples(t *testing.T) {
+	tests := []struct {
+		name               string
+		rows               []string
+		cost, discount     int
+		regions, enclosing int
+	}{
+		{"small", []string{"AAAA", "BBCD", "BBCC", "EEEC"}, 140, 80, 5, 0},
+		{"nested", []string{"OOOOO", "OXOXO", "OOOOO", "OXOXO", "OOOOO"}, 772, 436, 5, 1},
+		{"e-shape", []string{"EEEEE", "EXXXX", "EEEEE", "EXXXX", "EEEEE"}, 692, 236, 3, 0},
+		{"diagonal", []string{"AAAAAA", "AAABBA", "AAABBA", "ABBAAA", "ABBAAA", "AAAAAA"}, 1184, 368, 3, 1},
+		{"large", []string{
+			"RRRRIICCFF", "RRRRIICCCF", "VVRRRCCFFF", "VVRCCCJFFF", "VVVVCJJCFE",
+			"VVIVCCJJEE", "VVIIICJJEE", "MIIIIIJJEE", "MIIISIJEEE", "MMMISSJEEE",
+		}, 1930, 1206, 11, 0},
+	}
+
+	for _, test := range tests {
+		l := label(t, test.rows...)
+		if cost, discount := prices(l); cost != test.cost || discount != test.discount {
+			t.Errorf("%s: expected prices %d and %d, got %d and %d", test.name, test.cost, test.discount, cost, discount)
+		}
+		if len(l.Regions) != test.regions {
+			t.Errorf("%s: expected %d regions, got %d", test.name, test.regions, len(l.Regions))
+		}
+		enclosing := 0
+		for _, region := range l.Regions {
+			if len(region.Holes) > 0 {
+				enclosing++
+			}
+		}
+		if enclosing != test.enclosing {
+			t.Errorf("%s: expected %d regions with holes, got %d", test.name, test.enclosing, enclosing)
+		}
+	}
+}
+
+func TestLabel_HolesAndNesting(t *testing.T) {
+	l := label(t,
+		"AAAAAAA",
+		"ABBBAZA",
+		"ABCBAAA",
+		"ABBBAYA",
+		"AAAAAYA",
+		"AAAAAAA",
+	)
+
+	a, b, c := l.At(0, 0), l.At(1, 1), l.At(2, 2)
+	z, y := l.At(1, 5), l.At(3, 5)
+	if l.Regions[a].Parent != -1 || l.Regions[b].Parent != a || l.Regions[c].Parent != b {
+		t.Errorf("Expected C inside B inside A, got parents %d, %d, %d", l.Regions[a].Parent, l.Regions[b].Parent, l.Regions[c].Parent)
+	}
+	if !l.Encloses(a, c) || l.Encloses(c, a) || l.Encloses(b, z) {
+		t.Errorf("Unexpected enclosure between A, B, C and Z")
+	}
+
+	holes := l.Regions[a].Holes
+	if len(holes) != 3 {
+		t.Fatalf("Expected A to have 3 holes, got %+v", holes)
+	}
+	want := map[int]int{b: 9, z: 1, y: 2}
+	for _, hole := range holes {
+		if len(hole.Regions) != 1 || want[hole.Regions[0]] != hole.Area {
+			t.Errorf("Unexpected hole %+v", hole)
+		}
+	}
+
+	if bounds := l.Regions[b].Bounds; bounds != (Bounds{MinRow: 1, MinCol: 1, MaxRow: 3, MaxCol: 3}) {
+		t.Errorf("Unexpected bounds for B: %+v", bounds)
+	}
+	if region := l.Regions[b]; region.Area != 8 || region.Perimeter != 16 || region.Sides != 8 {
+		t.Errorf("Expected B to have area 8, perimeter 16 and 8 sides, got %+v", region)
+	}
+	if l.At(-1, 0) != -1 {
+		t.Errorf("Expected cells outside the grid to have no region")
+	}
+}
+
+func TestLabel_IntValues(t *testing.T) {
+	grid := util.NewGrid[int](3, 3)
+	grid.Set(1, 1, 7)
+	l := Label(grid)
+	if len(l.Regions) != 2 || l.Regions[1].Value != 7 || l.Regions[1].Parent != 0 {
+		t.Errorf("Expected a single 7 inside a ring of 0s, got %+v", l.Regions)
+	}
+}
+
+// TestLabel_MatchesFloodFill compares enclosure against flood filling from every region while avoiding another
+func TestLabel_MatchesFloodFill(t *testing.T) {
+	r := rand.New(rand.NewSource(12))
+	for n := 0; n < 300; n++ {
+		rows := make([]string, 1+r.Intn(7))
+		cols := 1 + r.Intn(7)
+		for i := range rows {
+			var builder strings.Builder
+			for j := 0; j < cols; j++ {
+				builder.WriteByte("ABC"[r.Intn(3)])
+			}
+			rows[i] = builder.String()
+		}
+		l := label(t, rows...)
+
+		for inner := range l.Regions {
+			for outer := range l.Regions {
+				if outer == inner {
+					continue
+				}
+				if want := !escapes(l, inner, outer); l.Encloses(outer, inner) != want {
+					t.Fatalf("grid %v: expected Encloses(%d, %d) to be %v", rows, outer, inner, want)
+				}
+			}
+
+			cells := 0
+			for _, cell := range l.Regions[inner].Cells {
+				if l.At(cell[0], cell[1]) == inner {
+					cells++
+				}
+			}
+			if cells != l.Regions[inner].Area {
+				t.Fatalf("grid %v: region %d has cells labeled with another region", rows, inner)
+			}
+		}
+	}
+}
+
+// escapes checks if region can reach the edge of the grid without stepping on blocker
+func escapes(l *Labeling[string], region, blocker int) bool {
+	start := l.Regions[region].Cells[0]
+	seen := map[[2]int]bool{start: true}
+	queue := [][2]int{start}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		for _, d := range orthogonal {
+			next := [2]int{cell[0] + d[0], cell[1] + d[1]}
+			id := l.At(next[0], next[1])
+			if id == -1 {
+				return true
+			}
+			if id != blocker && !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
+
+func TestLabel_Empty(t *testing.T) {
+	l := Label(util.NewGrid[string](0, 0))
+	if len(l.Regions) != 0 || !slices.Equal(l.labels, []int{}) {
+		t.Errorf("Expected no regions, got %+v", l.Regions)
+	}
+}
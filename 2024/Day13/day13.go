@@ -1,17 +1,16 @@
 package main
 
 import (
+	"2024/solution"
 	"2024/util"
 	"fmt"
+	"log/slog"
 	"math"
-	"regexp"
+	"os"
 	"strings"
 )
 
 const (
-	patternA = `Button A: X\+(\d+), Y\+(\d+)\s*`
-	patternB = `Button B: X\+(\d+), Y\+(\d+)\s*`
-	prize    = `Prize: X=(\d+), Y=(\d+)`
 	attempts = 100
 	bigPrize = 10000000000000
 )
@@ -24,14 +23,19 @@ Advent of Code Day 13:
 */
 func main() {
 	input := util.ReadInput(util.Parameter())
-	machines := captureMachines(strings.Join(input, "\n"))
-	part1(machines)
-	part2(machines)
+	machines, err := captureMachines(input)
+	if err != nil {
+		slog.Error("Unable to read the claw machines", "err", err)
+		os.Exit(1)
+	}
+	sol := solution.New(13)
+	sol.Part(1, func() any { return part1(machines) })
+	sol.Part(2, func() any { return part2(machines) })
 }
 
 // part1 solves the problem for the first part where attempts are limited to 100.
 // It calculates the minimum total cost for all machines if solutions are possible.
-func part1(machines [][6]int) {
+func part1(machines [][6]int) int {
 	minCost := 0
 	solutions := make([][2]int, 0)
 	for _, m := range machines {
@@ -42,7 +46,7 @@ func part1(machines [][6]int) {
 		}
 	}
 
-	fmt.Println("(part 1) Ans :", minCost)
+	return minCost
 }
 
 // solveLinearCombinations attempts to solve the claw machine problem for a single machine
@@ -66,7 +70,7 @@ func solveLinearCombinations(xA int, yA int, xB int, yB int, xP int, yP int) (in
 
 		bY := (yP - yA*attempt) / yB
 
-		if bX == bY && bX >= 0 {
+		if bX == bY && bX >= 0 && bX <= attempts {
 			cost := 3*attempt + bX
 			if cost < minCost {
 				minCost = cost
@@ -80,7 +84,7 @@ func solveLinearCombinations(xA int, yA int, xB int, yB int, xP int, yP int) (in
 
 // part2 solves the problem for the second part where prize positions are greatly increased.
 // It calculates the minimum total cost for all machines with no restriction on attempts.
-func part2(machines [][6]int) {
+func part2(machines [][6]int) int64 {
 	var minCost int64 = 0
 
 	for _, m := range machines {
@@ -91,47 +95,58 @@ func part2(machines [][6]int) {
 		}
 	}
 
-	fmt.Println("(part 2) Ans :", minCost)
+	return minCost
 }
 
 // solveLargerPrize solves the claw machine problem for a single machine with larger prize positions.
-// It calculates the cost directly using linear algebra and floating-point arithmetic.
+// It calculates the presses directly with Cramer's rule, staying in integers since the prizes are too far away for
+// floating-point arithmetic to tell whole presses apart.
 // Returns the minimum cost or -1 if no solution exists.
 func solveLargerPrize(xA, yA, xB, yB int, pX, pY int64) int64 {
-	fax := float64(xA)
-	fay := float64(yA)
-	fbx := float64(xB)
-	fby := float64(yB)
-	fpx := float64(pX)
-	fpy := float64(pY)
-
-	n := (fpx*fby - fpy*fbx) / (fax*fby - fay*fbx)
-	m := (fpy - n*fay) / fby
-
-	if n == math.Trunc(n) && m == math.Trunc(m) {
-		return int64(3*n + m)
+	ax, ay, bx, by := int64(xA), int64(yA), int64(xB), int64(yB)
+
+	det := ax*by - ay*bx
+	if det == 0 {
+		return int64(-1)
+	}
+	nNum := pX*by - pY*bx
+	mNum := ax*pY - ay*pX
+	if nNum%det != 0 || mNum%det != 0 {
+		return int64(-1)
 	}
 
-	return int64(-1)
+	n, m := nNum/det, mNum/det
+	if n < 0 || m < 0 {
+		return int64(-1)
+	}
+	return 3*n + m
 }
 
-// captureMachines parses the raw input string to extract machine configurations.
-// Each machine's configuration is represented as a 6-element array of integers
-// containing coefficients for A, B, and prize positions.
-func captureMachines(input string) [][6]int {
+// captureMachines parses the input lines to extract machine configurations.
+// Each machine is a block of lines separated by a blank line, its configuration is represented as a 6-element
+// array of integers containing coefficients for A, B, and prize positions.
+// The buttons have to move the claw forward on both axes and the prize can't be behind the claw.
+func captureMachines(input []string) ([][6]int, error) {
 	machines := make([][6]int, 0)
 
-	pattern := regexp.MustCompile(patternA + patternB + prize)
-
-	matches := pattern.FindAllStringSubmatch(input, -1)
-
-	for _, m := range matches {
-		var values [6]int
-		for i, v := range m[1:] {
-			values[i] = util.ParseInt(v)
+	for i, block := range util.Blocks(input) {
+		values, err := util.Ints(strings.Join(block, " "))
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 6 {
+			return nil, fmt.Errorf("machine %d has %d numbers, expected 6", i+1, len(values))
+		}
+		for j, value := range values {
+			if j < 4 && value <= 0 {
+				return nil, fmt.Errorf("machine %d has a button that moves %d, it has to move forward", i+1, value)
+			}
+			if value < 0 {
+				return nil, fmt.Errorf("machine %d has its prize at %d, it can't be behind the claw", i+1, value)
+			}
 		}
-		machines = append(machines, values)
+		machines = append(machines, [6]int(values))
 	}
 
-	return machines
+	return machines, nil
 }
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/differential"
+	"2024/fuzztest"
+	"2024/gen"
+	"2024/puzzle"
+	"2024/util"
+	"math/rand/v2"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 13)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(machines(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 13)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(machines(b, input))
+	}
+}
+
+func machines(tb testing.TB, input []string) [][6]int {
+	m, err := captureMachines(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return m
+}
+
+func TestProperty_FewestTokens(t *testing.T) {
+	for seed := uint64(0); seed < 2000; seed++ {
+		claws := gen.NewClawMachines(gen.New(seed), 20, 0, attempts)
+		if got := part1(machines(t, claws.Lines)); got != claws.Cost {
+			t.Fatalf("Seed %d: expected part 1 to be %d for %d winnable prizes, got %d", seed, claws.Cost, claws.Winnable, got)
+		}
+
+		claws = gen.NewClawMachines(gen.New(seed), 20, bigPrize, 0)
+		if got := part2(machines(t, claws.Lines)); got != int64(claws.Cost) {
+			t.Fatalf("Seed %d: expected part 2 to be %d for %d winnable prizes, got %d", seed, claws.Cost, claws.Winnable, got)
+		}
+	}
+}
+
+// fewestTokens tries every number of A presses, the reference for solveLargerPrize
+func fewestTokens(m [6]int) int64 {
+	fewest := int64(-1)
+	for a := 0; a*m[0] <= m[4] && a*m[1] <= m[5]; a++ {
+		restX, restY := m[4]-a*m[0], m[5]-a*m[1]
+		if restX%m[2] != 0 || restX/m[2]*m[3] != restY {
+			continue
+		}
+		if cost := int64(3*a + restX/m[2]); fewest == -1 || cost < fewest {
+			fewest = cost
+		}
+	}
+	return fewest
+}
+
+// parallel reports whether the buttons move along the same line, which the puzzle never does
+func parallel(m [6]int) bool {
+	return m[0]*m[3] == m[1]*m[2]
+}
+
+func TestDifferential_SolveLargerPrize(t *testing.T) {
+	differential.Run(t, differential.Test[[6]int]{
+		Generate: func(r *rand.Rand) [6]int {
+			var m [6]int
+			for m[0] == 0 || parallel(m) {
+				m = [6]int{1 + r.IntN(20), 1 + r.IntN(20), 1 + r.IntN(20), 1 + r.IntN(20), 0, 0}
+			}
+			if r.IntN(2) == 0 {
+				a, b := r.IntN(50), r.IntN(50)
+				m[4], m[5] = a*m[0]+b*m[2], a*m[1]+b*m[3]
+			} else {
+				m[4], m[5] = r.IntN(1000), r.IntN(1000)
+			}
+			return m
+		},
+		Shrink: func(m [6]int) [][6]int {
+			toReturn := make([][6]int, 0)
+			for i := range m {
+				low := 1
+				if i >= 4 {
+					low = 0
+				}
+				for _, smaller := range differential.Smaller(m[i], low) {
+					candidate := m
+					candidate[i] = smaller
+					if !parallel(candidate) {
+						toReturn = append(toReturn, candidate)
+					}
+				}
+			}
+			return toReturn
+		},
+		Reference: func(m [6]int) any { return fewestTokens(m) },
+		Optimized: func(m [6]int) any {
+			return solveLargerPrize(m[0], m[1], m[2], m[3], int64(m[4]), int64(m[5]))
+		},
+		Cases: 5000,
+	})
+}
+
+func FuzzCaptureMachines(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		machines, err := captureMachines(lines)
+		if err != nil {
+			return
+		}
+		if len(machines) != len(util.Blocks(lines)) {
+			t.Fatalf("Expected a machine per block, got %d for %d blocks", len(machines), len(util.Blocks(lines)))
+		}
+		// no button is pressed more than 100 times in part 1, and A costs 3 tokens where B costs 1
+		if tokens := part1(machines); tokens < 0 || tokens > 400*len(machines) {
+			t.Errorf("Expected between 0 and %d tokens, got %d", 400*len(machines), tokens)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		if example.Part == 2 {
+			return part2(machines(t, input))
+		}
+		return part1(machines(t, input))
+	})
+}
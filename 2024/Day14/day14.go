@@ -1,14 +1,16 @@
 package main
 
 import (
+	"2024/recorder"
+	"2024/solution"
 	"2024/util"
 	"fmt"
-	"regexp"
-	"strings"
+	"log/slog"
+	"os"
+	"slices"
 )
 
 const (
-	pattern = `-?\d+`
 	seconds = 100
 )
 
@@ -27,47 +29,60 @@ type robot struct {
 }
 
 func main() {
-	robots := grabRobots(util.ReadInput(util.Parameter()))
 	wide, tall := 101, 103
-	//part1(robots, wide, tall, seconds)
-	part2(robots, wide, tall, 100000)
+	robots, err := grabRobots(util.ReadInput(util.Parameter()), wide, tall)
+	if err != nil {
+		slog.Error("Unable to read the robots", "err", err)
+		os.Exit(1)
+	}
+	rec := recorder.FromFlags()
+	sol := solution.New(14)
+	// both parts move the robots in place, so each one gets its own copy. Only part 2 is recorded
+	sol.Part(1, func() any { return part1(slices.Clone(robots), wide, tall, seconds) })
+	sol.PartWithNotes(2, func() (any, []string) { return part2(slices.Clone(robots), wide, tall, 100000, rec) })
+	if err := rec.Close(); err != nil {
+		slog.Error("Unable to save recording", "err", err)
+	}
 }
 
-func part2(robots []robot, wide int, tall int, seconds int) {
+// part2 runs the simulation and records every second, the Christmas tree has to be spotted in the recording
+// so there is no answer to return. The robots are back where they started after wide*tall seconds, so the recording
+// stops there rather than keeping frames it already has
+func part2(robots []robot, wide int, tall int, seconds int, rec *recorder.Recorder) (any, []string) {
+	rec.Limit(wide * tall)
 	for second := 0; second < seconds; second++ {
 		for index, rob := range robots {
 			robots[index] = moveRobot(rob, wide, tall)
 		}
 
-		fmt.Println()
-		fmt.Println("Second: ", second)
-		printGrid(robots, wide, tall)
-		fmt.Println()
+		if rec.Enabled() && second < wide*tall {
+			rec.Capture(renderGrid(robots, wide, tall), fmt.Sprintf("Second: %d", second+1))
+		}
+	}
+
+	if !rec.Enabled() {
+		return nil, []string{"Run with -record to look for the Christmas tree"}
 	}
+	return nil, []string{fmt.Sprintf("Recorded %d seconds, look for the Christmas tree in the recording", len(rec.Frames()))}
 }
 
 // part1 runs the simulation for a specified number of seconds, moves robots
 // based on their velocities, and calculates a safety factor by counting
-// robots in each quadrant. The results are printed to the console.
-func part1(robots []robot, wide, tall, seconds int) {
+// robots in each quadrant. The safety factor is returned.
+func part1(robots []robot, wide, tall, seconds int) int {
 	for second := 0; second < seconds; second++ {
 		for index, rob := range robots {
 			robots[index] = moveRobot(rob, wide, tall)
 		}
-		printGrid(robots, wide, tall)
-		fmt.Println()
 	}
 	q1, q2, q3, q4 := findQuadrant(robots, wide, tall)
 
-	//printGrid(robots, wide, tall)
-
-	fmt.Println("(part 1) Ans: ", q1*q2*q3*q4)
+	return q1 * q2 * q3 * q4
 }
 
-// printGrid renders the current positions of the robots on a grid and
-// displays it in the console. Empty cells are marked with '.', and cells
-// with robots are marked with '#'.
-func printGrid(robots []robot, wide int, tall int) {
+// renderGrid renders the current positions of the robots on a grid.
+// Empty cells are marked with '.', and cells with robots are marked with '#'.
+func renderGrid(robots []robot, wide int, tall int) [][]string {
 	grid := make([][]string, tall)
 
 	for i := 0; i < tall; i++ {
@@ -84,10 +99,7 @@ func printGrid(robots []robot, wide int, tall int) {
 		}
 	}
 
-	for _, row := range grid {
-		fmt.Println(strings.Join(row, ""))
-	}
-
+	return grid
 }
 
 // findQuadrant calculates the number of robots in each of the four quadrants
@@ -116,10 +128,10 @@ func findQuadrant(robots []robot, wide int, tall int) (int, int, int, int) {
 }
 
 // moveRobot updates a robot's position based on its velocity and applies
-// wrapping to ensure the robot stays within the grid boundaries.
+// wrapping to ensure the robot stays within the grid boundaries, even when it moves further than the grid in a second.
 func moveRobot(rob robot, wide int, tall int) robot {
-	x := (rob.position[0] + rob.velocity[0] + wide) % wide
-	y := (rob.position[1] + rob.velocity[1] + tall) % tall
+	x := ((rob.position[0]+rob.velocity[0])%wide + wide) % wide
+	y := ((rob.position[1]+rob.velocity[1])%tall + tall) % tall
 
 	rob.position[0] = x
 	rob.position[1] = y
@@ -128,17 +140,22 @@ func moveRobot(rob robot, wide int, tall int) robot {
 
 // grabRobots parses a list of input strings to create a slice of robots. Each
 // string should contain position and velocity values in the format
-// "p=x,y v=dx,dy"
-func grabRobots(input []string) []robot {
+// "p=x,y v=dx,dy", and every robot has to start inside the wide x tall space.
+func grabRobots(input []string, wide int, tall int) ([]robot, error) {
 	toReturn := make([]robot, 0)
 
-	regex := regexp.MustCompile(pattern)
-
-	for _, line := range input {
-		matches := regex.FindAllStringSubmatch(line, -1)
-		x, y := util.ParseInt(matches[0][0]), util.ParseInt(matches[1][0])
-		dx, dy := util.ParseInt(matches[2][0]), util.ParseInt(matches[3][0])
-		toReturn = append(toReturn, robot{[2]int{x, y}, [2]int{dx, dy}})
+	for i, line := range input {
+		values, err := util.Ints(line)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 4 {
+			return nil, fmt.Errorf("line %d has %d numbers, expected 4", i+1, len(values))
+		}
+		if values[0] < 0 || values[0] >= wide || values[1] < 0 || values[1] >= tall {
+			return nil, fmt.Errorf("line %d has a robot at %d,%d, outside of the %dx%d space", i+1, values[0], values[1], wide, tall)
+		}
+		toReturn = append(toReturn, robot{[2]int{values[0], values[1]}, [2]int{values[2], values[3]}})
 	}
-	return toReturn
+	return toReturn, nil
 }
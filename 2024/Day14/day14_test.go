@@ -0,0 +1,68 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 14)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(robots(b, input), 101, 103, seconds)
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 14)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(robots(b, input), 101, 103, 100000, nil)
+	}
+}
+
+func robots(tb testing.TB, input []string) []robot {
+	r, err := grabRobots(input, 101, 103)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return r
+}
+
+func FuzzGrabRobots(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		// the example room is 11 wide and 7 tall
+		robots, err := grabRobots(lines, 11, 7)
+		if err != nil {
+			return
+		}
+		if len(robots) != len(lines) {
+			t.Fatalf("Expected a robot per line, got %d for %d lines", len(robots), len(lines))
+		}
+		// however fast they go, the robots wrap around and stay in the room
+		for second := 0; second < 10; second++ {
+			for i, rob := range robots {
+				robots[i] = moveRobot(rob, 11, 7)
+				if x, y := robots[i].position[0], robots[i].position[1]; x < 0 || x >= 11 || y < 0 || y >= 7 {
+					t.Fatalf("Expected robot %d to stay in the room, it went to %d,%d", i, x, y)
+				}
+			}
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		// the example room is 11 wide and 7 tall, and only part 1 has an answer
+		robots, err := grabRobots(input, 11, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if example.Part == 2 {
+			return nil
+		}
+		return part1(robots, 11, 7, seconds)
+	})
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"2024/Day15/warehouse"
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"strings"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 15)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		grid, directions := warehouseInput(b, input)
+		part1(grid, directions, nil)
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 15)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		grid, directions := warehouseInput(b, input)
+		part2(doubleGrid(grid), directions, nil)
+	}
+}
+
+func warehouseInput(tb testing.TB, input []string) ([][]string, []string) {
+	grid, directions, err := parseInput(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return grid, directions
+}
+
+// drawnBoxes counts the boxes in the drawing of the warehouse, a wide box is drawn as "[]"
+func drawnBoxes(w *warehouse.Warehouse) int {
+	drawn := w.String()
+	return strings.Count(drawn, box) + strings.Count(drawn, "[")
+}
+
+func FuzzParseInput(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		grid, directions, err := parseInput(lines)
+		if err != nil {
+			return
+		}
+		for _, size := range [][][]string{grid, doubleGrid(grid)} {
+			w, err := warehouse.New(size)
+			if err != nil {
+				t.Fatalf("Expected the parsed warehouse to be accepted, got %v", err)
+			}
+			boxes := drawnBoxes(w)
+			if len(w.Boxes()) != boxes {
+				t.Fatalf("Expected a box for each of the %d drawn, got %d", boxes, len(w.Boxes()))
+			}
+			// without walls around it the robot can try to leave the grid, those moves fail and the rest keep every box
+			for _, dir := range directions {
+				w.Move(dir)
+			}
+			if drawnBoxes(w) != boxes {
+				t.Fatalf("Expected the %d boxes to still be in the warehouse, got\n%s", boxes, w)
+			}
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		grid, directions := warehouseInput(t, input)
+		if example.Part == 2 {
+			return part2(doubleGrid(grid), directions, nil)
+		}
+		return part1(grid, directions, nil)
+	})
+}
@@ -0,0 +1,324 @@
+package warehouse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	wall     = "#"
+	robot    = "@"
+	box      = "O"
+	empty    = "."
+	leftBox  = "["
+	rightBox = "]"
+)
+
+// Directions maps the robot's move characters to a row and column step
+var Directions = map[string][2]int{
+	"^": {-1, 0},
+	"v": {1, 0},
+	"<": {0, -1},
+	">": {0, 1},
+}
+
+// Box is a box of any shape, given as the cells it covers
+type Box struct {
+	ID    int
+	Cells [][2]int
+}
+
+// BoxMove is a box that moved during a step
+type BoxMove struct {
+	ID       int
+	From, To [][2]int
+}
+
+// StepDiff is everything that changed during a single step, enough to replay it
+//   - Step: index of the step in the move log
+//   - Move: the move character
+//   - RobotFrom, RobotTo: where the robot was before and after the step
+//   - Boxes: every box that was pushed, empty if the push was blocked
+type StepDiff struct {
+	Step      int
+	Move      string
+	RobotFrom [2]int
+	RobotTo   [2]int
+	Boxes     []BoxMove
+}
+
+// Warehouse holds the walls, boxes and robot, and the log of moves so they can be undone and redone
+type Warehouse struct {
+	rows, cols int
+	walls      map[[2]int]bool
+	boxes      map[int]*Box
+	occupied   map[[2]int]int
+	robot      [2]int
+	log        []StepDiff
+	undone     []StepDiff
+}
+
+// New creates a Warehouse out of the puzzle grid. "O" is a 1 wide box and "[]" is a 2 wide box
+func New(grid [][]string) (*Warehouse, error) {
+	w := &Warehouse{walls: make(map[[2]int]bool), boxes: make(map[int]*Box), occupied: make(map[[2]int]int), robot: [2]int{-1, -1}}
+	w.rows = len(grid)
+
+	for r, row := range grid {
+		w.cols = max(w.cols, len(row))
+		for c := 0; c < len(row); c++ {
+			switch row[c] {
+			case wall:
+				w.walls[[2]int{r, c}] = true
+			case robot:
+				w.robot = [2]int{r, c}
+			case box:
+				if _, err := w.AddBox([][2]int{{r, c}}); err != nil {
+					return nil, err
+				}
+			case leftBox:
+				if c+1 >= len(row) || row[c+1] != rightBox {
+					return nil, fmt.Errorf("box at %d,%d is missing its right side", r, c)
+				}
+				if _, err := w.AddBox([][2]int{{r, c}, {r, c + 1}}); err != nil {
+					return nil, err
+				}
+				c++
+			case rightBox:
+				return nil, fmt.Errorf("box at %d,%d is missing its left side", r, c)
+			case empty:
+			default:
+				return nil, fmt.Errorf("unknown cell %q at %d,%d", row[c], r, c)
+			}
+		}
+	}
+
+	if w.robot[0] == -1 {
+		return nil, fmt.Errorf("no robot was found in the warehouse")
+	}
+	return w, nil
+}
+
+// AddBox adds a box covering the given cells and returns its ID. Cells don't have to be connected, but they have to
+// be inside the warehouse and can't overlap a wall, the robot or another box
+func (w *Warehouse) AddBox(cells [][2]int) (int, error) {
+	if len(cells) == 0 {
+		return 0, fmt.Errorf("a box has to cover at least one cell")
+	}
+	covered := make(map[[2]int]bool, len(cells))
+	for _, cell := range cells {
+		switch {
+		case !w.inBounds(cell):
+			return 0, fmt.Errorf("box cell %d,%d is outside of the %dx%d warehouse", cell[0], cell[1], w.rows, w.cols)
+		case w.walls[cell]:
+			return 0, fmt.Errorf("box cell %d,%d is a wall", cell[0], cell[1])
+		case cell == w.robot:
+			return 0, fmt.Errorf("box cell %d,%d is where the robot is", cell[0], cell[1])
+		case covered[cell]:
+			return 0, fmt.Errorf("box covers cell %d,%d more than once", cell[0], cell[1])
+		}
+		if other, ok := w.occupied[cell]; ok {
+			return 0, fmt.Errorf("box cell %d,%d overlaps box %d", cell[0], cell[1], other)
+		}
+		covered[cell] = true
+	}
+
+	id := len(w.boxes)
+	b := &Box{ID: id, Cells: append([][2]int{}, cells...)}
+	w.boxes[id] = b
+	for _, cell := range b.Cells {
+		w.occupied[cell] = id
+	}
+	return id, nil
+}
+
+// inBounds reports whether the cell is inside the warehouse's grid
+func (w *Warehouse) inBounds(cell [2]int) bool {
+	return cell[0] >= 0 && cell[0] < w.rows && cell[1] >= 0 && cell[1] < w.cols
+}
+
+// Robot returns the robot's position
+func (w *Warehouse) Robot() [2]int {
+	return w.robot
+}
+
+// Boxes returns every box ordered by ID
+func (w *Warehouse) Boxes() []Box {
+	boxes := make([]Box, 0, len(w.boxes))
+	for id := 0; id < len(w.boxes); id++ {
+		boxes = append(boxes, Box{ID: id, Cells: append([][2]int{}, w.boxes[id].Cells...)})
+	}
+	return boxes
+}
+
+// Move tries to move the robot one step, pushing every box in the way.
+//
+// The push is resolved as one dependency closure: starting from the cell in front of the robot, every box that is in
+// the way is collected along with every box in the way of those boxes. If any of them would run into a wall nothing
+// moves, otherwise they all shift together. A move that would take the robot or a box out of the grid is an error, the
+// warehouse is left as it was. Making a move clears the redo history
+func (w *Warehouse) Move(move string) (StepDiff, error) {
+	dir, ok := Directions[move]
+	if !ok {
+		return StepDiff{}, fmt.Errorf("unknown move %q", move)
+	}
+
+	diff := StepDiff{Step: len(w.log), Move: move, RobotFrom: w.robot, RobotTo: w.robot, Boxes: make([]BoxMove, 0)}
+	pushed, ok, err := w.closure(w.robot, dir)
+	if err != nil {
+		return StepDiff{}, fmt.Errorf("move %q: %w", move, err)
+	}
+	if ok {
+		for _, id := range pushed {
+			from := w.boxes[id].Cells
+			to := make([][2]int, len(from))
+			for i, cell := range from {
+				to[i] = [2]int{cell[0] + dir[0], cell[1] + dir[1]}
+			}
+			diff.Boxes = append(diff.Boxes, BoxMove{ID: id, From: from, To: to})
+		}
+		diff.RobotTo = [2]int{w.robot[0] + dir[0], w.robot[1] + dir[1]}
+	}
+
+	w.apply(diff, false)
+	w.log = append(w.log, diff)
+	w.undone = w.undone[:0]
+	return diff, nil
+}
+
+// closure collects every box that has to move when something at from moves in dir, in ID order.
+// Returns false if any of them (or the robot) would run into a wall, and an error if any would leave the grid
+func (w *Warehouse) closure(from [2]int, dir [2]int) ([]int, bool, error) {
+	pushed := make(map[int]bool)
+	queue := [][2]int{from}
+
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+
+		next := [2]int{cell[0] + dir[0], cell[1] + dir[1]}
+		if w.walls[next] {
+			return nil, false, nil
+		}
+		if !w.inBounds(next) {
+			return nil, false, fmt.Errorf("%d,%d would be pushed out of the warehouse", cell[0], cell[1])
+		}
+		id, ok := w.occupied[next]
+		if !ok || pushed[id] {
+			continue
+		}
+		pushed[id] = true
+		queue = append(queue, w.boxes[id].Cells...)
+	}
+
+	ids := make([]int, 0, len(pushed))
+	for id := range pushed {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, true, nil
+}
+
+// apply moves the robot and boxes as described by the diff, or back if reverse is set
+func (w *Warehouse) apply(diff StepDiff, reverse bool) {
+	for _, moved := range diff.Boxes {
+		for _, cell := range moved.From {
+			delete(w.occupied, cell)
+		}
+		for _, cell := range moved.To {
+			delete(w.occupied, cell)
+		}
+	}
+	for _, moved := range diff.Boxes {
+		cells := moved.To
+		if reverse {
+			cells = moved.From
+		}
+		w.boxes[moved.ID].Cells = cells
+		for _, cell := range cells {
+			w.occupied[cell] = moved.ID
+		}
+	}
+
+	if reverse {
+		w.robot = diff.RobotFrom
+	} else {
+		w.robot = diff.RobotTo
+	}
+}
+
+// Undo reverts the last move, returns false if there is nothing to undo
+func (w *Warehouse) Undo() (StepDiff, bool) {
+	if len(w.log) == 0 {
+		return StepDiff{}, false
+	}
+	diff := w.log[len(w.log)-1]
+	w.log = w.log[:len(w.log)-1]
+	w.apply(diff, true)
+	w.undone = append(w.undone, diff)
+	return diff, true
+}
+
+// Redo replays the last undone move, returns false if there is nothing to redo
+func (w *Warehouse) Redo() (StepDiff, bool) {
+	if len(w.undone) == 0 {
+		return StepDiff{}, false
+	}
+	diff := w.undone[len(w.undone)-1]
+	w.undone = w.undone[:len(w.undone)-1]
+	w.apply(diff, false)
+	w.log = append(w.log, diff)
+	return diff, true
+}
+
+// Log returns the diff of every move made so far, in order
+func (w *Warehouse) Log() []StepDiff {
+	return append([]StepDiff{}, w.log...)
+}
+
+// GPS sums 100 * row + column of the top left cell of every box
+func (w *Warehouse) GPS() int {
+	total := 0
+	for _, b := range w.boxes {
+		top := b.Cells[0]
+		for _, cell := range b.Cells[1:] {
+			if cell[0] < top[0] || (cell[0] == top[0] && cell[1] < top[1]) {
+				top = cell
+			}
+		}
+		total += 100*top[0] + top[1]
+	}
+	return total
+}
+
+// String renders the warehouse like the puzzle does. 1 wide boxes are drawn as "O", 2 wide boxes as "[]" and
+// any other shape with the last digit of its ID
+func (w *Warehouse) String() string {
+	grid := make([][]string, w.rows)
+	for r := range grid {
+		grid[r] = strings.Split(strings.Repeat(empty, w.cols), "")
+	}
+	for cell := range w.walls {
+		grid[cell[0]][cell[1]] = wall
+	}
+	for _, b := range w.boxes {
+		for i, cell := range b.Cells {
+			switch {
+			case len(b.Cells) == 1:
+				grid[cell[0]][cell[1]] = box
+			case len(b.Cells) == 2 && b.Cells[0][0] == b.Cells[1][0] && b.Cells[1][1] == b.Cells[0][1]+1:
+				grid[cell[0]][cell[1]] = []string{leftBox, rightBox}[i]
+			default:
+				grid[cell[0]][cell[1]] = fmt.Sprint(b.ID % 10)
+			}
+		}
+	}
+	grid[w.robot[0]][w.robot[1]] = robot
+
+	var builder strings.Builder
+	for _, row := range grid {
+		builder.WriteString(strings.Join(row, ""))
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,218 @@
+package warehouse
+
+import (
+	"strings"
+	"testing"
+)
+
+var exampleGrid = []string{
+	"##########",
+	"#..O..O.O#",
+	"#......O.#",
+	"#.OO..O.O#",
+	"#..O@..O.#",
+	"#O#..O...#",
+	"#O..O..O.#",
+	"#.OO.O.OO#",
+	"#....O...#",
+	"##########",
+}
+
+const exampleMoves = "<vv>^<v^>v>^vv^v>v<>v^v<v<^vv<<<^><<><>>v<vvv<>^v^>^<<<><<v<<<v^vv^v>^" +
+	"vvv<<^>^v^^><<>>><>^<<><^vv^^<>vvv<>><^^v>^>vv<>v<<<<v<^v>^<^^>>>^<v<v" +
+	"><>vv>v^v^<>><>>>><^^>vv>v<^^^>>v^v^<^^>v^^>v^<^v>v<>>v^v^<v>v^^<^^vv<" +
+	"<<v<^>>^^^^>>>v^<>vvv^><v<<<>^^^vv^<vvv>^>v<^^^^v<>^>vvvv><>>v^<<^^^^^" +
+	"^><^><>>><>^^<<^^v>>><^<v>^<vv>>v>>>^v><>^v><<<<v>>v<v<v>vvv>^<><<>^><" +
+	"^>><>^v<><^vvv<^^<><v<<<<<><^v<<<><<<^^<v<^^^><^>>^<v^><<<^>>^v<v^v<v^" +
+	">^>>^v>vv>^<<^v<>><<><<v<<v><>v<^vv<<<>^^v^>^^>>><<^v>>v^v><^^>>^<>vv^" +
+	"<><^^>^^^<><vvvvv^v<v<<>^v<v>v<<^><<><<><<<^^<<<^<<>><<><^^^>^^<>^>v<>" +
+	"^^>vv<^v^v<vv>^<><v<^v>^^^>>>^^vvv^>vvv<>>>^<^>>>>>^<<^v>^vvv<>^<><<v>" +
+	"v^^>>><<^^<>>^v^<v^vv<>v^<<>^<^v^v><^<<<><<^<v><v<>vv>>v><v^<vv<>v^<<^"
+
+func newWarehouse(t *testing.T, lines []string) *Warehouse {
+	grid := make([][]string, 0)
+	for _, line := range lines {
+		grid = append(grid, strings.Split(line, ""))
+	}
+	w, err := New(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+// addBox adds a box that is expected to fit and returns its ID
+func addBox(t *testing.T, w *Warehouse, cells [][2]int) int {
+	t.Helper()
+	id, err := w.AddBox(cells)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+// widen doubles the example the same way part 2 does
+func widen(lines []string) []string {
+	replacer := strings.NewReplacer("#", "##", "O", "[]", ".", "..", "@", "@.")
+	wide := make([]string, 0, len(lines))
+	for _, line := range lines {
+		wide = append(wide, replacer.Replace(line))
+	}
+	return wide
+}
+
+func TestWarehouse_Example(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		lines []string
+		gps   int
+	}{
+		{"narrow", exampleGrid, 10092},
+		{"wide", widen(exampleGrid), 9021},
+	} {
+		w := newWarehouse(t, test.lines)
+		for _, move := range strings.Split(exampleMoves, "") {
+			if _, err := w.Move(move); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := w.GPS(); got != test.gps {
+			t.Errorf("%s: expected GPS %d, got %d", test.name, test.gps, got)
+		}
+	}
+}
+
+func TestWarehouse_UndoRedo(t *testing.T) {
+	w := newWarehouse(t, widen(exampleGrid))
+	initial := w.String()
+
+	moves := strings.Split(exampleMoves[:200], "")
+	states := []string{initial}
+	for _, move := range moves {
+		if _, err := w.Move(move); err != nil {
+			t.Fatal(err)
+		}
+		states = append(states, w.String())
+	}
+
+	for i := len(moves) - 1; i >= 0; i-- {
+		if _, ok := w.Undo(); !ok {
+			t.Fatalf("Expected to undo move %d", i)
+		}
+		if w.String() != states[i] {
+			t.Fatalf("State after undoing move %d doesn't match", i)
+		}
+	}
+	if _, ok := w.Undo(); ok {
+		t.Error("Expected nothing left to undo")
+	}
+
+	for i := 0; i < len(moves); i++ {
+		if _, ok := w.Redo(); !ok {
+			t.Fatalf("Expected to redo move %d", i)
+		}
+	}
+	if w.String() != states[len(states)-1] {
+		t.Error("State after redoing every move doesn't match")
+	}
+}
+
+func TestWarehouse_Polyomino(t *testing.T) {
+	w := newWarehouse(t, []string{
+		"#######",
+		"#.....#",
+		"#.....#",
+		"#..@..#",
+		"#######",
+	})
+	// an L shaped box above the robot, hooked over the cell to its right
+	addBox(t, w, [][2]int{{2, 3}, {1, 3}, {1, 4}})
+	addBox(t, w, [][2]int{{2, 4}})
+
+	diff, err := w.Move("^")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Boxes) != 0 || diff.RobotTo != diff.RobotFrom {
+		t.Errorf("Expected the push to be blocked by the wall, got %+v", diff)
+	}
+
+	diff, err = w.Move("<")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.RobotTo != [2]int{3, 2} {
+		t.Errorf("Expected robot to move left, got %+v", diff)
+	}
+
+	w = newWarehouse(t, []string{
+		"#######",
+		"#.....#",
+		"#.....#",
+		"#.....#",
+		"#.....#",
+		"#..@..#",
+		"#######",
+	})
+	addBox(t, w, [][2]int{{4, 3}, {4, 4}})
+	other := addBox(t, w, [][2]int{{3, 4}, {2, 4}})
+	diff, err = w.Move("^")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Boxes) != 2 || diff.Boxes[1].ID != other || diff.Boxes[1].To[1] != [2]int{1, 4} {
+		t.Errorf("Expected both boxes to be pushed up, got %+v", diff)
+	}
+}
+
+func TestWarehouse_AddBoxOverlap(t *testing.T) {
+	w := newWarehouse(t, []string{
+		"#####",
+		"#.O.#",
+		"#..@#",
+		"#####",
+	})
+	addBox(t, w, [][2]int{{2, 1}, {2, 2}})
+	for _, cells := range [][][2]int{
+		{{1, 1}, {1, 2}},
+		{{2, 2}},
+		{{1, 3}, {2, 3}},
+		{{0, 1}},
+		{{1, 1}, {5, 1}},
+		{{1, 1}, {1, 1}},
+		{},
+	} {
+		if _, err := w.AddBox(cells); err == nil {
+			t.Errorf("Expected an error adding a box at %v", cells)
+		}
+	}
+	if got := len(w.Boxes()); got != 2 {
+		t.Errorf("Expected the rejected boxes to be left out, got %d boxes", got)
+	}
+}
+
+func TestWarehouse_OutOfBounds(t *testing.T) {
+	// no walls, so nothing stops the robot or the box at the edge
+	w := newWarehouse(t, []string{
+		"..",
+		"O@",
+	})
+	for _, move := range []string{"<", "v", ">"} {
+		if _, err := w.Move(move); err == nil {
+			t.Errorf("Expected moving %s to leave the grid and fail", move)
+		}
+	}
+	if len(w.Log()) != 0 || w.Robot() != [2]int{1, 1} || w.String() != "..\nO@\n" {
+		t.Errorf("Expected the failed moves to leave the warehouse alone, got\n%s", w.String())
+	}
+
+	if _, err := w.Move("^"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Move("^"); err == nil {
+		t.Error("Expected moving past the top row to fail")
+	}
+	if w.String() != ".@\nO.\n" {
+		t.Errorf("Expected the robot on the top row, got\n%s", w.String())
+	}
+}
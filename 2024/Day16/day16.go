@@ -2,12 +2,15 @@ package main
 
 import (
 	"2024/Day16/datastructure"
+	"2024/recorder"
+	"2024/solution"
 	"2024/util"
 	"container/heap"
 	"fmt"
+	"log/slog"
 	"math"
+	"os"
 	"slices"
-	"strings"
 )
 
 const (
@@ -37,22 +40,30 @@ type costInfo struct {
 }
 
 func main() {
-	grid := util.TransformStringSliceInto2DMatrix(util.ReadInput(util.Parameter()))
-	startPOS, endPOS := findPOS(grid, start), findPOS(grid, end)
-	part1(grid, startPOS, endPOS)
-	part2(grid, startPOS, endPOS)
+	grid, startPOS, endPOS, err := parseMaze(util.ReadInput(util.Parameter()))
+	if err != nil {
+		slog.Error("Unable to read the maze", "err", err)
+		os.Exit(1)
+	}
+	rec := recorder.FromFlags()
+	sol := solution.New(16)
+	sol.Part(1, func() any { return part1(grid, startPOS, endPOS) })
+	sol.Part(2, func() any { return part2(grid, startPOS, endPOS, rec) })
+	if err := rec.Close(); err != nil {
+		slog.Error("Unable to save recording", "err", err)
+	}
 }
 
-func part1(grid [][]string, start [2]int, end [2]int) {
-	cost := dijkstra(grid, start, end)
-	fmt.Println("(part 1) Ans:", cost)
+func part1(grid [][]string, start [2]int, end [2]int) int {
+	return dijkstra(grid, start, end)
 }
 
-func part2(grid [][]string, start [2]int, end [2]int) {
+func part2(grid [][]string, start [2]int, end [2]int, rec *recorder.Recorder) int {
 	spaces := findAllMinPathsAndSpaces(grid, start, end)
-	fmt.Println("(part 2) Ans:", spaces.Size())
+	rec.Capture(grid, "(part 2) Maze")
 	addVisitedSpots(grid, spaces)
-	printGrid(grid)
+	rec.Capture(grid, "(part 2) Tiles on a best path")
+	return spaces.Size()
 }
 
 func findAllMinPathsAndSpaces(grid [][]string, start [2]int, end [2]int) util.HashSet {
@@ -184,6 +195,29 @@ func dijkstra(grid [][]string, start [2]int, end [2]int) int {
 	return -1
 }
 
+// parseMaze reads the maze and finds the start and end tiles, the maze has to be rectangular with exactly one of each
+func parseMaze(input []string) ([][]string, [2]int, [2]int, error) {
+	grid, err := util.CharGrid(input)
+	if err != nil {
+		return nil, [2]int{}, [2]int{}, err
+	}
+
+	for _, tile := range []string{start, end} {
+		count := 0
+		for _, row := range grid {
+			for _, cell := range row {
+				if cell == tile {
+					count++
+				}
+			}
+		}
+		if count != 1 {
+			return nil, [2]int{}, [2]int{}, fmt.Errorf("found %d %s tiles in the maze, expected 1", count, tile)
+		}
+	}
+	return grid, findPOS(grid, start), findPOS(grid, end), nil
+}
+
 func findPOS(grid [][]string, s string) [2]int {
 	rows := len(grid)
 	cols := len(grid[0])
@@ -204,9 +238,3 @@ func addVisitedSpots(grid [][]string, seen util.HashSet) {
 		grid[step[0]][step[1]] = "O"
 	}
 }
-
-func printGrid(grid [][]string) {
-	for _, row := range grid {
-		fmt.Println(strings.Join(row, ""))
-	}
-}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(maze(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		grid, startPOS, endPOS := maze(b, input)
+		part2(grid, startPOS, endPOS, nil)
+	}
+}
+
+func maze(tb testing.TB, input []string) ([][]string, [2]int, [2]int) {
+	grid, startPOS, endPOS, err := parseMaze(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return grid, startPOS, endPOS
+}
+
+func FuzzParseMaze(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		grid, startPOS, endPOS, err := parseMaze(lines)
+		if err != nil {
+			return
+		}
+		if len(grid) != len(lines) || grid[startPOS[0]][startPOS[1]] != start || grid[endPOS[0]][endPOS[1]] != end {
+			t.Fatalf("Expected a row per line with the start at %v and the end at %v", startPOS, endPOS)
+		}
+		// the reindeer can't get to the end in fewer steps than it is away, and every step of a best path is a tile
+		distance := max(endPOS[0]-startPOS[0], startPOS[0]-endPOS[0]) + max(endPOS[1]-startPOS[1], startPOS[1]-endPOS[1])
+		cost := part1(grid, startPOS, endPOS)
+		if cost != -1 && cost < distance {
+			t.Fatalf("Expected the lowest score %d to be at least the distance %d", cost, distance)
+		}
+		if tiles := part2(grid, startPOS, endPOS, nil); cost != -1 && tiles < distance+1 {
+			t.Errorf("Expected at least %d tiles on the best paths, got %d", distance+1, tiles)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		grid, startPOS, endPOS := maze(t, input)
+		if example.Part == 2 {
+			return part2(grid, startPOS, endPOS, nil)
+		}
+		return part1(grid, startPOS, endPOS)
+	})
+}
@@ -2,38 +2,57 @@ package main
 
 import (
 	computer "2024/Day17/threebitcomputer"
+	"2024/solution"
 	"2024/util"
-	"fmt"
-	"regexp"
+	"log/slog"
+	"os"
 	"slices"
+	"strings"
 )
 
-const pattern = `-?\d+`
-
 func main() {
 	input := util.ReadInput(util.Parameter())
-	a, b, c, program := parseInput(input)
-	part1(a, b, c, program)
-	part2(program)
+	a, b, c, program, err := parseInput(input)
+	if err != nil {
+		slog.Error("Unable to read the program", "err", err)
+		os.Exit(1)
+	}
+	sol := solution.New(17)
+	sol.Part(1, func() any { return part1(a, b, c, program) })
+	sol.Part(2, func() any { return part2(program) })
 }
 
-func part1(a int, b int, c int, program []int) {
+// part1 runs the program and returns its output joined by commas
+func part1(a int, b int, c int, program []int) string {
 	comp := computer.NewComputer(a, b, c, false)
 	comp.Run(program)
-	comp.PrintOutput()
+	return strings.Join(comp.GetOutput(), ",")
+}
 
+// part2 finds the lowest A that makes the program output itself, warning first when the program isn't shaped the way
+// the search needs
+func part2(program []int) int {
+	for _, problem := range computer.Analyze(program).SolverProblems() {
+		slog.Warn("The program doesn't fit the search for A, the answer may be wrong", "problem", problem)
+	}
+	return lowestA(program, program)
 }
 
-func part2(program []int) {
+// lowestA finds the smallest value of register A that makes the program output target, -1 if there is none.
+// The search assumes the program is a loop that outputs a value from the low bits of A and then shifts A right by 3
+func lowestA(program []int, target []int) int {
 	results := make([]int, 0)
-	values := make([]int, len(program))
-	copy(values, program)
-	findSolutions(0, program, values, &results, 1)
+	values := make([]int, len(target))
+	copy(values, target)
+	findSolutions(0, program, values, &results)
+	if len(results) == 0 {
+		return -1
+	}
 	slices.Sort(results)
-	fmt.Println("Part 2: Ans", results[0])
+	return results[0]
 }
 
-func findSolutions(a int, program []int, values []int, results *[]int, level int) {
+func findSolutions(a int, program []int, values []int, results *[]int) {
 	if len(values) == 0 {
 		return
 	}
@@ -44,6 +63,10 @@ func findSolutions(a int, program []int, values []int, results *[]int, level int
 	candidates := util.NewHashSet()
 
 	for i := 0; i < 8; i++ {
+		// A can't start with a 0 digit, it would have one digit fewer and output one value fewer
+		if a == 0 && i == 0 && len(values) > 0 {
+			continue
+		}
 		compt := computer.NewComputer(a+i, 0, 0, false)
 		compt.OneTime = true
 		compt.Run(program)
@@ -51,8 +74,8 @@ func findSolutions(a int, program []int, values []int, results *[]int, level int
 		firstVal := util.ParseInt(result[0])
 		if firstVal == val {
 			candidates.Add(i)
-			if level == len(program) {
-				fmt.Println("valid a", a+i)
+			if len(values) == 0 {
+				slog.Debug("Found a value for A", "a", a+i)
 				*results = append(*results, a+i)
 			}
 		}
@@ -62,33 +85,12 @@ func findSolutions(a int, program []int, values []int, results *[]int, level int
 		candidate := can.(int)
 		newValues := make([]int, len(values))
 		copy(newValues, values)
-		findSolutions((a+candidate)*8, program, newValues, results, level+1)
+		findSolutions((a+candidate)*8, program, newValues, results)
 	}
 
 }
 
-func parseInput(input []string) (int, int, int, []int) {
-	var a int
-	var b int
-	var c int
-	program := make([]int, 0)
-	reg := regexp.MustCompile(pattern)
-	match1 := reg.FindAllStringSubmatch(input[0], -1)
-	match2 := reg.FindAllStringSubmatch(input[1], -1)
-	match3 := reg.FindAllStringSubmatch(input[2], -1)
-
-	a = util.ParseInt(match1[0][0])
-	b = util.ParseInt(match2[0][0])
-	c = util.ParseInt(match3[0][0])
-
-	for i := 4; i < len(input); i++ {
-		match := reg.FindAllStringSubmatch(input[i], -1)
-		for _, m := range match {
-			for _, num := range m {
-				program = append(program, util.ParseInt(num))
-			}
-		}
-	}
-
-	return a, b, c, program
+// parseInput reads the registers and the program, the computer reads the same input in the aoc vm debugger
+func parseInput(input []string) (int, int, int, []int, error) {
+	return computer.ParseInput(input)
 }
@@ -0,0 +1,172 @@
+package main
+
+import (
+	computer "2024/Day17/threebitcomputer"
+	"2024/bench"
+	"2024/differential"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"2024/util"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 17)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		regA, regB, regC, program, err := parseInput(input)
+		if err != nil {
+			b.Fatal(err)
+		}
+		part1(regA, regB, regC, program)
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 17)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _, program, err := parseInput(input)
+		if err != nil {
+			b.Fatal(err)
+		}
+		part2(program)
+	}
+}
+
+// outputCase is a program shaped like the puzzle inputs and an output wanted from it
+type outputCase struct {
+	program []int
+	target  []int
+}
+
+// run runs the whole program and returns its output
+func run(a int, program []int) []int {
+	comp := computer.NewComputer(a, 0, 0, false)
+	comp.Run(program)
+	output := make([]int, 0)
+	for _, value := range comp.GetOutput() {
+		n, _ := strconv.Atoi(value)
+		output = append(output, n)
+	}
+	return output
+}
+
+// everyA runs the program for every A with as many octal digits as the target has values, the program outputs one
+// value per digit. It is the reference for lowestA
+func everyA(c outputCase) int {
+	limit := 1
+	for range c.target {
+		limit *= 8
+	}
+	for a := 0; a < limit; a++ {
+		if slices.Equal(run(a, c.program), c.target) {
+			return a
+		}
+	}
+	return -1
+}
+
+// outputProgram builds the loop every puzzle input is made of: B gets the low bits of A, is mixed with A shifted
+// right by B and two constants, gets printed, and A drops its low 3 bits until it is 0. The middle instructions come
+// in any order
+func outputProgram(r *rand.Rand) []int {
+	middle := [][]int{{1, r.IntN(8)}, {4, r.IntN(8)}, {0, 3}}
+	r.Shuffle(len(middle), func(i, j int) { middle[i], middle[j] = middle[j], middle[i] })
+	program := []int{2, 4, 1, r.IntN(8), 7, 5}
+	for _, instruction := range middle {
+		program = append(program, instruction...)
+	}
+	return append(program, 5, 5, 3, 0)
+}
+
+// TestOutputProgram_Analyze checks the analyzer agrees that the generated programs are ones the search handles
+func TestOutputProgram_Analyze(t *testing.T) {
+	r := rand.New(rand.NewPCG(17, 17))
+	for i := 0; i < 100; i++ {
+		program := outputProgram(r)
+		if problems := computer.Analyze(program).SolverProblems(); len(problems) != 0 {
+			t.Errorf("Expected %v to fit the search, got %v", program, problems)
+		}
+	}
+}
+
+func TestDifferential_LowestA(t *testing.T) {
+	differential.Run(t, differential.Test[outputCase]{
+		Generate: func(r *rand.Rand) outputCase {
+			program := outputProgram(r)
+			if r.IntN(4) == 0 {
+				target := make([]int, 1+r.IntN(4))
+				for i := range target {
+					target[i] = r.IntN(8)
+				}
+				return outputCase{program, target}
+			}
+			return outputCase{program, run(r.IntN(8*8*8*8), program)}
+		},
+		Shrink: func(c outputCase) []outputCase {
+			toReturn := make([]outputCase, 0)
+			if len(c.target) > 1 {
+				for _, target := range differential.Without(c.target) {
+					toReturn = append(toReturn, outputCase{c.program, target})
+				}
+			}
+			for i := 0; i < len(c.program); i += 2 {
+				if c.program[i] != 1 && c.program[i] != 4 {
+					continue
+				}
+				for _, smaller := range differential.Smaller(c.program[i+1], 0) {
+					program := slices.Clone(c.program)
+					program[i+1] = smaller
+					toReturn = append(toReturn, outputCase{program, c.target})
+				}
+			}
+			return toReturn
+		},
+		Reference: func(c outputCase) any { return everyA(c) },
+		Optimized: func(c outputCase) any { return lowestA(c.program, c.target) },
+	})
+}
+
+func FuzzParseInput(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		a, b, c, program, err := parseInput(lines)
+		if err != nil {
+			return
+		}
+		if registers, _ := util.Ints(strings.Join(lines, " ")); !slices.Equal(registers, append([]int{a, b, c}, program...)) {
+			t.Fatalf("Expected the registers and program to be the numbers of the input %v, got %d %d %d %v", registers, a, b, c, program)
+		}
+		computer.Analyze(program).SolverProblems()
+		// only a program without a jump is sure to halt, those run every out instruction once
+		outs := 0
+		for i := 0; i < len(program); i += 2 {
+			switch program[i] {
+			case 3:
+				return
+			case 5:
+				outs++
+			}
+		}
+		if output := part1(a, b, c, program); outs > 0 && strings.Count(output, ",") != outs-1 || outs == 0 && output != "" {
+			t.Errorf("Expected %d values from the program, got %q", outs, output)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		a, b, c, program, err := parseInput(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if example.Part == 2 {
+			return part2(program)
+		}
+		return part1(a, b, c, program)
+	})
+}
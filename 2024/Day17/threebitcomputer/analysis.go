@@ -0,0 +1,481 @@
+package threebitcomputer
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Exit stands for the program halting in the successors of a block
+const Exit = -1
+
+// Block is a run of instructions that always run one after the other
+//   - Instructions: the instructions in order, only the last one can be a jnz
+//   - Next: the starts of the blocks that can run after it, Exit when the program can halt after it
+type Block struct {
+	Instructions []Instruction
+	Next         []int
+}
+
+// Start returns the instruction pointer of the first instruction
+func (b *Block) Start() int {
+	return b.Instructions[0].IP
+}
+
+// Last returns the instruction the block ends with
+func (b *Block) Last() Instruction {
+	return b.Instructions[len(b.Instructions)-1]
+}
+
+// Loop is a jump back to instructions that already ran
+//   - Header: the start of the block the loop jumps back to
+//   - Latch: where the last instruction before the jump back is, the jnz unless the loop runs on into its header
+//   - Body: the starts of the blocks in the loop, sorted
+//   - Shift: how many bits A moves right each iteration, -1 when A changes some other way
+type Loop struct {
+	Header int
+	Latch  int
+	Body   []int
+	Shift  int
+}
+
+// Dependency is what the value an out instruction outputs depends on
+//   - Loop: the index of the innermost loop the out is in, -1 when it isn't in one. The bits are bits of A at the
+//     start of that loop's iteration, or at the start of the program
+//   - Bits: the bits of A the value depends on, sorted
+//   - Other: the value also depends on B or C from before the iteration, or on which way a jump went
+type Dependency struct {
+	Instruction Instruction
+	Loop        int
+	Bits        []int
+	Other       bool
+}
+
+// Analysis is what can be told about a program without running it
+//   - Blocks: the control-flow graph of the instructions the program can reach from ip 0, sorted by start
+//   - Loops: the loops, sorted by header then latch
+//   - Dead: instructions at even positions that never run
+//   - Misaligned: instructions that run from an odd position, because a jnz jumps into the middle of an instruction
+//   - Outputs: what each out instruction that can run depends on, in ip order
+type Analysis struct {
+	program    []int
+	Blocks     []*Block
+	Loops      []Loop
+	Dead       []Instruction
+	Misaligned []Instruction
+	Outputs    []Dependency
+}
+
+// Analyze builds the control-flow graph of the program, finds its loops and dead code and works out which bits of A
+// every output depends on. A jnz either jumps or halts on this computer, it never goes on to the next instruction
+func Analyze(program []int) *Analysis {
+	analysis := &Analysis{
+		program:    program,
+		Blocks:     make([]*Block, 0),
+		Loops:      make([]Loop, 0),
+		Dead:       make([]Instruction, 0),
+		Misaligned: make([]Instruction, 0),
+		Outputs:    make([]Dependency, 0),
+	}
+	next := flow(program)
+	analysis.buildBlocks(next)
+	analysis.findLoops()
+	for _, instruction := range Disassemble(program) {
+		if _, ok := next[instruction.IP]; !ok {
+			analysis.Dead = append(analysis.Dead, instruction)
+		}
+	}
+	for _, block := range analysis.Blocks {
+		if block.Start()%move != 0 {
+			analysis.Misaligned = append(analysis.Misaligned, block.Instructions...)
+		}
+	}
+	analysis.trackA()
+	return analysis
+}
+
+// successors returns where the program can go after the instruction at ip
+func successors(program []int, ip int) []int {
+	instruction, _ := Decode(program, ip)
+	if instruction.Opcode == 3 {
+		if _, ok := Decode(program, instruction.Operand); ok {
+			return []int{instruction.Operand, Exit}
+		}
+		return []int{Exit}
+	}
+	if _, ok := Decode(program, ip+move); ok && instruction.Opcode >= 0 && instruction.Opcode < len(mnemonics) {
+		return []int{ip + move}
+	}
+	return []int{Exit}
+}
+
+// flow returns the successors of every instruction the program reaches from ip 0
+func flow(program []int) map[int][]int {
+	next := make(map[int][]int)
+	if _, ok := Decode(program, 0); !ok {
+		return next
+	}
+	queue := []int{0}
+	next[0] = nil
+	for len(queue) > 0 {
+		ip := queue[0]
+		queue = queue[1:]
+		next[ip] = successors(program, ip)
+		for _, n := range next[ip] {
+			if _, seen := next[n]; n != Exit && !seen {
+				next[n] = nil
+				queue = append(queue, n)
+			}
+		}
+	}
+	return next
+}
+
+// buildBlocks cuts the reached instructions into blocks. A block starts at ip 0, where a jump lands and where more
+// than one instruction leads
+func (an *Analysis) buildBlocks(next map[int][]int) {
+	previous := make(map[int][]int)
+	for ip, targets := range next {
+		for _, n := range targets {
+			if n != Exit {
+				previous[n] = append(previous[n], ip)
+			}
+		}
+	}
+	starts := func(ip int) bool {
+		if ip == 0 || len(previous[ip]) != 1 || previous[ip][0] != ip-move {
+			return true
+		}
+		before, _ := Decode(an.program, ip-move)
+		return before.Opcode == 3
+	}
+
+	ips := make([]int, 0, len(next))
+	for ip := range next {
+		ips = append(ips, ip)
+	}
+	slices.Sort(ips)
+	for _, ip := range ips {
+		if !starts(ip) {
+			continue
+		}
+		block := &Block{Instructions: make([]Instruction, 0)}
+		for {
+			instruction, _ := Decode(an.program, ip)
+			block.Instructions = append(block.Instructions, instruction)
+			if targets := next[ip]; instruction.Opcode == 3 || targets[0] == Exit || starts(targets[0]) {
+				block.Next = targets
+				break
+			}
+			ip += move
+		}
+		an.Blocks = append(an.Blocks, block)
+	}
+}
+
+// block returns the block that starts at ip
+func (an *Analysis) block(start int) *Block {
+	i, _ := slices.BinarySearchFunc(an.Blocks, start, func(b *Block, start int) int { return b.Start() - start })
+	return an.Blocks[i]
+}
+
+// findLoops looks for jumps back to a block that is still being walked from ip 0. The body is every block that can
+// get to the jump without going through the header
+func (an *Analysis) findLoops() {
+	if len(an.Blocks) == 0 {
+		return
+	}
+	previous := make(map[int][]int)
+	for _, block := range an.Blocks {
+		for _, n := range block.Next {
+			if n != Exit {
+				previous[n] = append(previous[n], block.Start())
+			}
+		}
+	}
+
+	walking, walked := make(map[int]bool), make(map[int]bool)
+	var walk func(start int)
+	walk = func(start int) {
+		walking[start] = true
+		block := an.block(start)
+		for _, n := range block.Next {
+			switch {
+			case n == Exit || walked[n]:
+			case walking[n]:
+				an.Loops = append(an.Loops, Loop{Header: n, Latch: block.Last().IP, Body: loopBody(n, start, previous), Shift: -1})
+			default:
+				walk(n)
+			}
+		}
+		walking[start], walked[start] = false, true
+	}
+	walk(0)
+
+	slices.SortFunc(an.Loops, func(x, y Loop) int {
+		if x.Header != y.Header {
+			return x.Header - y.Header
+		}
+		return x.Latch - y.Latch
+	})
+}
+
+// loopBody returns the blocks from header to the block that jumps back, walking the edges backwards from the latch
+func loopBody(header, latch int, previous map[int][]int) []int {
+	body := map[int]bool{header: true}
+	queue := []int{latch}
+	for len(queue) > 0 {
+		start := queue[0]
+		queue = queue[1:]
+		if body[start] {
+			continue
+		}
+		body[start] = true
+		queue = append(queue, previous[start]...)
+	}
+	starts := make([]int, 0, len(body))
+	for start := range body {
+		starts = append(starts, start)
+	}
+	slices.Sort(starts)
+	return starts
+}
+
+// propagate runs the registers through the blocks of region from entry until they stop changing, without following
+// the edges skip leaves out. It returns the registers before every instruction it reached
+func (an *Analysis) propagate(entry int, start registers, region map[int]bool, skip func(from *Block, to int) bool) map[int]registers {
+	in := map[int]registers{entry: start}
+	before := make(map[int]registers)
+	queue := []int{entry}
+	for len(queue) > 0 {
+		block := an.block(queue[0])
+		queue = queue[1:]
+
+		state := in[block.Start()]
+		for _, instruction := range block.Instructions {
+			before[instruction.IP] = state
+			state = state.execute(instruction)
+		}
+		for _, n := range block.Next {
+			if n == Exit || !region[n] || skip(block, n) {
+				continue
+			}
+			joined := state
+			if previous, ok := in[n]; ok {
+				if joined = previous.join(state); joined == previous {
+					continue
+				}
+			}
+			in[n] = joined
+			queue = append(queue, n)
+		}
+	}
+	return before
+}
+
+// trackA works out what the registers depend on. Each loop is followed for one iteration from its header, and the
+// rest of the program from ip 0 without the jumps back. B and C are taken as unknown at the start of both, the input
+// sets them but anything that depends on them isn't worked out from A
+func (an *Analysis) trackA() {
+	if len(an.Blocks) == 0 {
+		return
+	}
+	everything := make(map[int]bool)
+	for _, block := range an.Blocks {
+		everything[block.Start()] = true
+	}
+	backwards := func(from *Block, to int) bool {
+		for _, loop := range an.Loops {
+			if loop.Header == to && loop.Latch == from.Last().IP {
+				return true
+			}
+		}
+		return false
+	}
+	program := an.propagate(0, iteration(), everything, backwards)
+
+	loops := make([]map[int]registers, len(an.Loops))
+	for i, loop := range an.Loops {
+		body := make(map[int]bool)
+		for _, start := range loop.Body {
+			body[start] = true
+		}
+		loops[i] = an.propagate(loop.Header, iteration(), body, func(_ *Block, to int) bool { return to == loop.Header })
+		if registers, ok := loops[i][loop.Latch]; ok {
+			an.Loops[i].Shift = shiftOf(registers[A])
+		}
+	}
+
+	for _, block := range an.Blocks {
+		for _, instruction := range block.Instructions {
+			if instruction.Opcode != 5 {
+				continue
+			}
+			dependency := Dependency{Instruction: instruction, Loop: an.innermost(block.Start())}
+			before := program[instruction.IP]
+			if dependency.Loop >= 0 {
+				before = loops[dependency.Loop][instruction.IP]
+			}
+			mask := low(before.combo(instruction.Operand)).depends()
+			dependency.Other = mask&other != 0
+			dependency.Bits = make([]int, 0)
+			for i := 0; i < width; i++ {
+				if mask>>i&1 == 1 {
+					dependency.Bits = append(dependency.Bits, i)
+				}
+			}
+			an.Outputs = append(an.Outputs, dependency)
+		}
+	}
+	slices.SortFunc(an.Outputs, func(x, y Dependency) int { return x.Instruction.IP - y.Instruction.IP })
+}
+
+// innermost returns the index of the smallest loop with the block in its body, -1 when no loop has it
+func (an *Analysis) innermost(start int) int {
+	found := -1
+	for i, loop := range an.Loops {
+		if _, in := slices.BinarySearch(loop.Body, start); in && (found < 0 || len(loop.Body) < len(an.Loops[found].Body)) {
+			found = i
+		}
+	}
+	return found
+}
+
+// SolverProblems lists the ways the program breaks what the search for the lowest A of Day 17 counts on: a single
+// loop jumping back to 0 from the last instruction, which is the only jump, outputs one value from A each iteration
+// and shifts A right by 3. None means the search finds the answer
+func (an *Analysis) SolverProblems() []string {
+	problems := make([]string, 0)
+	if len(an.Loops) != 1 {
+		return append(problems, fmt.Sprintf("the program has %d loops, the search expects exactly one", len(an.Loops)))
+	}
+
+	loop := an.Loops[0]
+	if last := len(an.program) - len(an.program)%move - move; loop.Header != 0 || loop.Latch != last {
+		problems = append(problems, fmt.Sprintf("the loop jumps from %d back to %d, the search expects jnz 0 as the last instruction", loop.Latch, loop.Header))
+	}
+	for _, block := range an.Blocks {
+		if last := block.Last(); last.Opcode == 3 && last.IP != loop.Latch {
+			problems = append(problems, fmt.Sprintf("the jnz at %d isn't the one that ends the loop", last.IP))
+		}
+	}
+	if len(an.Misaligned) > 0 {
+		problems = append(problems, fmt.Sprintf("a jump lands in the middle of an instruction at %d", an.Misaligned[0].IP))
+	}
+
+	switch loop.Shift {
+	case 3:
+	case -1:
+		problems = append(problems, "A changes some other way than being shifted right each iteration")
+	case 0:
+		problems = append(problems, "A doesn't change in the loop, so it never ends")
+	default:
+		problems = append(problems, fmt.Sprintf("A is shifted right by %d each iteration, the search expects 3", loop.Shift))
+	}
+
+	outputs := 0
+	for _, output := range an.Outputs {
+		ip := output.Instruction.IP
+		switch {
+		case output.Loop < 0:
+			problems = append(problems, fmt.Sprintf("the output at %d isn't in the loop", ip))
+			continue
+		case output.Other:
+			problems = append(problems, fmt.Sprintf("the output at %d depends on B or C from the iteration before", ip))
+		case len(output.Bits) == 0:
+			problems = append(problems, fmt.Sprintf("the output at %d doesn't depend on A", ip))
+		}
+		outputs++
+	}
+	if outputs != 1 {
+		problems = append(problems, fmt.Sprintf("the loop outputs %d values each iteration, the search expects 1", outputs))
+	}
+	return problems
+}
+
+// Report prints the analysis: the blocks, the loops, the dead code, what each output depends on, and whether the
+// search for the lowest A can be used on the program
+func (an *Analysis) Report(out io.Writer) {
+	fmt.Fprintln(out, "Blocks:")
+	for _, block := range an.Blocks {
+		next := make([]string, 0, len(block.Next))
+		for _, n := range block.Next {
+			if n == Exit {
+				next = append(next, "halt")
+			} else {
+				next = append(next, fmt.Sprint(n))
+			}
+		}
+		fmt.Fprintf(out, "  %d..%d -> %s\n", block.Start(), block.Last().IP, strings.Join(next, ", "))
+	}
+
+	fmt.Fprintln(out, "Loops:")
+	if len(an.Loops) == 0 {
+		fmt.Fprintln(out, "  none")
+	}
+	for _, loop := range an.Loops {
+		shift := "A changes some other way"
+		if loop.Shift == 0 {
+			shift = "A doesn't change"
+		} else if loop.Shift > 0 {
+			shift = fmt.Sprintf("A >> %d each iteration", loop.Shift)
+		}
+		fmt.Fprintf(out, "  %d back to %d, %s\n", loop.Latch, loop.Header, shift)
+	}
+
+	fmt.Fprintln(out, "Dead code:")
+	if len(an.Dead) == 0 {
+		fmt.Fprintln(out, "  none")
+	}
+	for _, instruction := range an.Dead {
+		fmt.Fprintf(out, "  %s\n", instruction)
+	}
+	if len(an.Misaligned) > 0 {
+		fmt.Fprintln(out, "Run from the middle of an instruction:")
+	}
+	for _, instruction := range an.Misaligned {
+		fmt.Fprintf(out, "  %s\n", instruction)
+	}
+
+	fmt.Fprintln(out, "Outputs:")
+	for _, output := range an.Outputs {
+		fmt.Fprintf(out, "  %s   depends on %s\n", output.Instruction, output.Describe())
+	}
+
+	problems := an.SolverProblems()
+	if len(problems) == 0 {
+		fmt.Fprintln(out, "The search for the lowest A works on this program")
+		return
+	}
+	fmt.Fprintln(out, "The search for the lowest A doesn't work on this program:")
+	for _, problem := range problems {
+		fmt.Fprintf(out, "  - %s\n", problem)
+	}
+}
+
+// Describe says what the output depends on, "A bits 0..9 (10 bits)"
+func (d Dependency) Describe() string {
+	ranges := make([]string, 0)
+	for i := 0; i < len(d.Bits); {
+		j := i
+		for j+1 < len(d.Bits) && d.Bits[j+1] == d.Bits[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, fmt.Sprint(d.Bits[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d..%d", d.Bits[i], d.Bits[j]))
+		}
+		i = j + 1
+	}
+
+	description := "no bits of A"
+	if len(d.Bits) > 0 {
+		description = fmt.Sprintf("A bits %s (%d bits)", strings.Join(ranges, ", "), len(d.Bits))
+	}
+	if d.Other {
+		description += " and B or C from before"
+	}
+	return description
+}
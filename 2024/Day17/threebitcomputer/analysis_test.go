@@ -0,0 +1,105 @@
+package threebitcomputer
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// puzzle is shaped like the puzzle inputs: bst A, bxl 5, cdv B, bxl 6, adv 3, bxc, out B, jnz 0
+var puzzle = []int{2, 4, 1, 5, 7, 5, 1, 6, 0, 3, 4, 0, 5, 5, 3, 0}
+
+func TestAnalyze_Puzzle(t *testing.T) {
+	analysis := Analyze(puzzle)
+	if len(analysis.Blocks) != 1 || !slices.Equal(analysis.Blocks[0].Next, []int{0, Exit}) {
+		t.Errorf("Expected a single block that loops or halts, got %+v", analysis.Blocks)
+	}
+	if want := []Loop{{Header: 0, Latch: 14, Body: []int{0}, Shift: 3}}; !reflect.DeepEqual(analysis.Loops, want) {
+		t.Errorf("Expected %+v, got %+v", want, analysis.Loops)
+	}
+	if len(analysis.Dead) != 0 {
+		t.Errorf("Expected no dead code, got %v", analysis.Dead)
+	}
+	// the low 3 bits of A pick the shift of C, which reads up to 7 bits further
+	if len(analysis.Outputs) != 1 || analysis.Outputs[0].Describe() != "A bits 0..9 (10 bits)" {
+		t.Errorf("Expected the output to depend on the low 10 bits of A, got %+v", analysis.Outputs)
+	}
+	if problems := analysis.SolverProblems(); len(problems) != 0 {
+		t.Errorf("Expected the search to work, got %v", problems)
+	}
+}
+
+func TestAnalyze_Outputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []int
+		want    string
+	}{
+		{"shifted", []int{0, 3, 5, 4, 3, 0}, "A bits 3..5 (3 bits)"},
+		{"xor", []int{2, 4, 1, 7, 5, 5, 0, 3, 3, 0}, "A bits 0..2 (3 bits)"},
+		{"literal", []int{5, 3, 0, 3, 3, 0}, "no bits of A"},
+		{"carried", []int{5, 5, 0, 3, 1, 1, 3, 0}, "no bits of A and B or C from before"},
+		{"shifted by B", []int{2, 4, 6, 5, 5, 5, 0, 3, 3, 0}, "A bits 0..9 (10 bits)"},
+		// the reserved combo operand 7 shifts by 7
+		{"gap", []int{2, 4, 7, 7, 4, 0, 5, 5, 0, 3, 3, 0}, "A bits 0..2, 7..9 (6 bits)"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outputs := Analyze(test.program).Outputs
+			if len(outputs) != 1 || outputs[0].Describe() != test.want {
+				t.Errorf("Expected %q, got %+v", test.want, outputs)
+			}
+		})
+	}
+}
+
+func TestAnalyze_DeadCodeAndMisaligned(t *testing.T) {
+	// adv 3, jnz 0, then an out nothing reaches
+	analysis := Analyze([]int{0, 3, 3, 0, 5, 4})
+	if len(analysis.Dead) != 1 || analysis.Dead[0].IP != 4 {
+		t.Errorf("Expected the out at 4 to be dead, got %v", analysis.Dead)
+	}
+
+	// bst A, jnz 5 lands on the 5 of its own operand
+	analysis = Analyze([]int{2, 4, 3, 5, 5, 4, 0, 3, 3, 0})
+	if len(analysis.Misaligned) == 0 || analysis.Misaligned[0].IP != 3 {
+		t.Errorf("Expected the instructions from 3 to be misaligned, got %v", analysis.Misaligned)
+	}
+	if len(analysis.Loops) != 1 || analysis.Loops[0].Shift != 0 {
+		t.Errorf("Expected a loop that leaves A alone, got %+v", analysis.Loops)
+	}
+}
+
+func TestAnalysis_SolverProblems(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []int
+		want    string
+	}{
+		{"no loop", []int{0, 3, 5, 4}, "the program has 0 loops"},
+		{"shift", example, "A is shifted right by 1 each iteration"},
+		{"two outputs", []int{0, 3, 5, 4, 5, 4, 3, 0}, "the loop outputs 2 values each iteration"},
+		{"carried", []int{5, 5, 0, 3, 1, 1, 3, 0}, "depends on B or C from the iteration before"},
+		{"not last", []int{0, 3, 5, 4, 3, 0, 5, 4}, "the loop jumps from 4 back to 0"},
+		{"not a shift", []int{0, 3, 1, 1, 6, 5, 0, 5, 5, 4, 3, 0}, "A changes some other way"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := Analyze(test.program).SolverProblems()
+			if !slices.ContainsFunc(problems, func(problem string) bool { return strings.Contains(problem, test.want) }) {
+				t.Errorf("Expected a problem with %q, got %v", test.want, problems)
+			}
+		})
+	}
+}
+
+func TestAnalysis_Report(t *testing.T) {
+	var out strings.Builder
+	Analyze(puzzle).Report(&out)
+	for _, want := range []string{"0..14 -> 0, halt", "14 back to 0, A >> 3 each iteration", "12: out B", "The search for the lowest A works"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in\n%s", want, out.String())
+		}
+	}
+}
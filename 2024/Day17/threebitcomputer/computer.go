@@ -2,6 +2,7 @@ package threebitcomputer
 
 import (
 	"fmt"
+	"log/slog"
 	"math"
 	"os"
 	"strconv"
@@ -25,6 +26,32 @@ const (
 	move  = 2
 )
 
+// Register names one of the three registers
+type Register int
+
+const (
+	A Register = iota
+	B
+	C
+)
+
+func (r Register) String() string {
+	return string(rune('A' + r))
+}
+
+// ParseRegister reads a register name, in either case
+func ParseRegister(name string) (Register, error) {
+	switch strings.ToUpper(name) {
+	case "A":
+		return A, nil
+	case "B":
+		return B, nil
+	case "C":
+		return C, nil
+	}
+	return 0, fmt.Errorf("no register %q, there are A, B and C", name)
+}
+
 // NewComputer creates a new Computer and sets the registers to the passed in values
 func NewComputer(a, b, c int, dump bool) *Computer {
 	cptr := new(Computer)
@@ -39,25 +66,72 @@ func NewComputer(a, b, c int, dump bool) *Computer {
 
 // Run runs the program and prints out logs if dump is set
 func (comp *Computer) Run(program []int) {
-	ip := 0
-
-	for ip < len(program) {
-		opcode := program[ip]
-		operand := program[ip+1]
-		result := comp.execute(opcode, operand)
-		if result == -1 {
-			fmt.Println("ERROR SOMETHING BAD HAPPENED!")
-			comp.debug(opcode, operand)
+	for !comp.Halted(program) {
+		opcode, operand := program[comp.ip], program[comp.ip+1]
+		if err := comp.Step(program); err != nil {
+			slog.Error("Unable to run the program", "opcode", opcode, "operand", operand, "a", comp.a, "b", comp.b, "c", comp.c, "err", err)
 			os.Exit(1)
 		}
 		if comp.dump {
 			comp.debug(opcode, operand)
 			fmt.Println()
 		}
-		ip = result
-		if comp.done {
-			break
-		}
+	}
+}
+
+// Step runs the instruction at the instruction pointer. It does nothing once the program has halted, and returns an
+// error without changing anything for an opcode that isn't 0 to 7
+func (comp *Computer) Step(program []int) error {
+	if comp.Halted(program) {
+		return nil
+	}
+	opcode, operand := program[comp.ip], program[comp.ip+1]
+	result := comp.execute(opcode, operand)
+	if result == -1 {
+		return fmt.Errorf("unknown opcode %d at %d", opcode, comp.ip)
+	}
+	comp.ip = result
+	return nil
+}
+
+// Halted says if the program has stopped, because a jnz didn't jump or because there is no full instruction at the
+// instruction pointer
+func (comp *Computer) Halted(program []int) bool {
+	return comp.done || comp.ip < 0 || comp.ip+1 >= len(program)
+}
+
+// IP returns the instruction pointer
+func (comp *Computer) IP() int {
+	return comp.ip
+}
+
+// SetIP moves the instruction pointer, and lets a halted program run again
+func (comp *Computer) SetIP(ip int) {
+	comp.ip = ip
+	comp.done = false
+}
+
+// Register returns the value of a register
+func (comp *Computer) Register(r Register) int {
+	switch r {
+	case A:
+		return comp.a
+	case B:
+		return comp.b
+	default:
+		return comp.c
+	}
+}
+
+// SetRegister changes the value of a register
+func (comp *Computer) SetRegister(r Register, value int) {
+	switch r {
+	case A:
+		comp.a = value
+	case B:
+		comp.b = value
+	default:
+		comp.c = value
 	}
 }
 
@@ -0,0 +1,104 @@
+package threebitcomputer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// example is the first example program of the puzzle, it outputs 4,6,3,5,6,3,5,2,1,0 with A = 729
+var example = []int{0, 1, 5, 4, 3, 0}
+
+func TestComputer_StepMatchesRun(t *testing.T) {
+	run := NewComputer(729, 0, 0, false)
+	run.Run(example)
+
+	step := NewComputer(729, 0, 0, false)
+	steps := 0
+	for !step.Halted(example) {
+		if err := step.Step(example); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		steps++
+	}
+	if got, want := strings.Join(step.GetOutput(), ","), strings.Join(run.GetOutput(), ","); got != want || want != "4,6,3,5,6,3,5,2,1,0" {
+		t.Errorf("Expected stepping to give %s like running, got %s", want, got)
+	}
+	if steps != 30 {
+		t.Errorf("Expected 30 instructions, got %d", steps)
+	}
+	if err := step.Step(example); err != nil || step.IP() != 4 {
+		t.Errorf("Expected a halted program to stay put, got ip %d, %v", step.IP(), err)
+	}
+}
+
+func TestComputer_Step_UnknownOpcode(t *testing.T) {
+	comp := NewComputer(0, 0, 0, false)
+	if err := comp.Step([]int{8, 0}); err == nil {
+		t.Errorf("Expected an error for opcode 8")
+	}
+	if comp.IP() != 0 {
+		t.Errorf("Expected the instruction pointer to stay at 0, got %d", comp.IP())
+	}
+}
+
+func TestComputer_Halted(t *testing.T) {
+	comp := NewComputer(1, 0, 0, false)
+	comp.SetIP(5)
+	if !comp.Halted(example) {
+		t.Errorf("Expected a program with no operand at the instruction pointer to be halted")
+	}
+	comp.SetIP(2)
+	if comp.Halted(example) {
+		t.Errorf("Expected the program to run from ip 2")
+	}
+}
+
+func TestComputer_Registers(t *testing.T) {
+	comp := NewComputer(1, 2, 3, false)
+	comp.SetRegister(B, 0o1234)
+	if a, b, c := comp.Register(A), comp.Register(B), comp.Register(C); a != 1 || b != 0o1234 || c != 3 {
+		t.Errorf("Unexpected registers %d %d %d", a, b, c)
+	}
+	if r, err := ParseRegister("c"); err != nil || r != C || r.String() != "C" {
+		t.Errorf("Expected register C, got %v, %v", r, err)
+	}
+	if _, err := ParseRegister("D"); err == nil {
+		t.Errorf("Expected an error for register D")
+	}
+}
+
+func TestDisassemble(t *testing.T) {
+	var listing []string
+	for _, instruction := range Disassemble([]int{2, 4, 1, 5, 7, 5, 4, 3, 0, 3, 5, 5, 3, 0}) {
+		listing = append(listing, instruction.Mnemonic()+" "+instruction.OperandText()+" : "+instruction.Effect())
+	}
+	want := []string{
+		"bst A : B = A & 7",
+		"bxl 5 : B = B ^ 5",
+		"cdv B : C = A >> B",
+		"bxc 3 : B = B ^ C",
+		"adv 3 : A = A >> 3",
+		"out B : output B & 7",
+		"jnz 0 : jump to 0 if A != 0",
+	}
+	if !slices.Equal(listing, want) {
+		t.Errorf("Expected\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(listing, "\n"))
+	}
+	if _, ok := Decode(example, 5); ok {
+		t.Errorf("Expected no instruction at the last value of the program")
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	a, b, c, program, err := ParseInput([]string{"Register A: 729", "Register B: 0", "Register C: 0", "", "Program: 0,1,5,4,3,0"})
+	if err != nil || a != 729 || b != 0 || c != 0 || !slices.Equal(program, example) {
+		t.Errorf("Unexpected %d %d %d %v, %v", a, b, c, program, err)
+	}
+
+	for _, program := range []string{"Program: 0,1,5", "Program: 0,8", "Program:"} {
+		if _, _, _, _, err := ParseInput([]string{"Register A: 1", "Register B: 0", "Register C: 0", "", program}); err == nil {
+			t.Errorf("Expected an error for %q", program)
+		}
+	}
+}
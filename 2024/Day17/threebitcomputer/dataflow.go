@@ -0,0 +1,189 @@
+package threebitcomputer
+
+import "math/bits"
+
+// width is how many bits a register can hold, the registers never go negative
+const width = 63
+
+// other marks a bit that depends on something besides A at the start of the iteration, B or C from before it or
+// which way a jump went. A has no bit 63, so it can share the masks of the A bits
+const other = uint64(1) << 63
+
+// value is what the analysis knows about a register, bit by bit
+//   - deps: for each bit, the bits of A at the start of the iteration it depends on
+//   - ones: the bits that depend on nothing and are 1, the bits that depend on nothing and aren't in it are 0
+type value struct {
+	deps [width]uint64
+	ones uint64
+}
+
+// registers are the values of A, B and C
+type registers [3]value
+
+// constant is a value that doesn't depend on anything
+func constant(n int) value {
+	return value{ones: uint64(n)}
+}
+
+// unknown is a value every bit of which depends on something other than A
+func unknown() value {
+	var v value
+	for i := range v.deps {
+		v.deps[i] = other
+	}
+	return v
+}
+
+// iteration is A, B and C at the start of an iteration: A is itself, and B and C are whatever they were left at
+func iteration() registers {
+	var a value
+	for i := range a.deps {
+		a.deps[i] = 1 << i
+	}
+	return registers{a, unknown(), unknown()}
+}
+
+// bit returns what bit i depends on and its value when it depends on nothing, bits past the width are 0
+func (v value) bit(i int) (uint64, uint64) {
+	if i >= width {
+		return 0, 0
+	}
+	return v.deps[i], v.ones >> i & 1
+}
+
+// depends returns everything any bit of the value depends on
+func (v value) depends() uint64 {
+	var mask uint64
+	for _, deps := range v.deps {
+		mask |= deps
+	}
+	return mask
+}
+
+// most returns the largest value v can hold, capped at the width since no shift goes further than that
+func (v value) most() int {
+	for i := width - 1; i >= 0; i-- {
+		if deps, one := v.bit(i); deps != 0 || one == 1 {
+			return min(1<<min(i+1, 7)-1, width)
+		}
+	}
+	return 0
+}
+
+// normal clears the ones of bits that depend on something, so values that mean the same compare equal
+func (v value) normal() value {
+	for i, deps := range v.deps {
+		if deps != 0 {
+			v.ones &^= 1 << i
+		}
+	}
+	return v
+}
+
+// shift is x >> s. When s isn't known every shift it could be is taken, and a bit that comes out different for
+// different shifts depends on s as well
+func shift(x, s value) value {
+	amount := s.depends()
+	lo, hi := int(min(s.ones, width)), int(min(s.ones, width))
+	if amount != 0 {
+		lo, hi = 0, s.most()
+	}
+
+	var result value
+	for i := 0; i < width; i++ {
+		deps, one := x.bit(i + lo)
+		varies := false
+		for k := lo + 1; k <= hi; k++ {
+			d, o := x.bit(i + k)
+			varies = varies || d != 0 || deps != 0 || o != one
+			deps |= d
+		}
+		if varies {
+			deps |= amount
+		}
+		result.deps[i] = deps
+		result.ones |= one << i
+	}
+	return result.normal()
+}
+
+// low is v & 7
+func low(v value) value {
+	var result value
+	copy(result.deps[:3], v.deps[:3])
+	result.ones = v.ones & 7
+	return result
+}
+
+// xor is x ^ y
+func xor(x, y value) value {
+	var result value
+	for i := range result.deps {
+		result.deps[i] = x.deps[i] | y.deps[i]
+	}
+	result.ones = x.ones ^ y.ones
+	return result.normal()
+}
+
+// join is a value that can be either x or y, a bit that is 0 in one and 1 in the other depends on which it was
+func join(x, y value) value {
+	var result value
+	for i := range result.deps {
+		result.deps[i] = x.deps[i] | y.deps[i]
+		if result.deps[i] == 0 && (x.ones^y.ones)>>i&1 == 1 {
+			result.deps[i] = other
+		}
+	}
+	result.ones = x.ones & y.ones
+	return result.normal()
+}
+
+// shiftOf returns how far v is A shifted right, -1 when it is anything else
+func shiftOf(v value) int {
+	if bits.OnesCount64(v.deps[0]) != 1 || v.deps[0]&other != 0 || v.ones != 0 {
+		return -1
+	}
+	k := bits.TrailingZeros64(v.deps[0])
+	for i, deps := range v.deps {
+		want := uint64(0)
+		if i+k < width {
+			want = 1 << (i + k)
+		}
+		if deps != want {
+			return -1
+		}
+	}
+	return k
+}
+
+func (r registers) join(with registers) registers {
+	return registers{join(r[A], with[A]), join(r[B], with[B]), join(r[C], with[C])}
+}
+
+// combo is the value of a combo operand, the computer uses 7 for the reserved operand 7
+func (r registers) combo(operand int) value {
+	if operand >= 4 && operand <= 6 {
+		return r[operand-4]
+	}
+	return constant(operand)
+}
+
+// execute returns the registers after the instruction, jnz and out don't change them
+func (r registers) execute(instruction Instruction) registers {
+	operand := r.combo(instruction.Operand)
+	switch instruction.Opcode {
+	case 0:
+		r[A] = shift(r[A], operand)
+	case 1:
+		r[B] = xor(r[B], constant(instruction.Operand))
+	case 2:
+		r[B] = low(operand)
+	case 4:
+		r[B] = xor(r[B], r[C])
+	case 6:
+		r[B] = shift(r[A], operand)
+	case 7:
+		r[C] = shift(r[A], operand)
+	}
+	return r
+}
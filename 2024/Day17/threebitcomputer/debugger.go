@@ -0,0 +1,386 @@
+package threebitcomputer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxContinue is how many instructions continue runs before it gives up, so a program that never halts can't hang
+// the debugger or fill the memory with its trace
+const maxContinue = 1_000_000
+
+const debuggerHelp = `Commands:
+  step [n]         run n instructions, 1 by default, and show what each one changed (s)
+  back [n]         undo the last n instructions, 1 by default
+  continue         run until a breakpoint, a watched register changes or the program halts (c)
+  break ip=N       stop before running the instruction at N, break alone lists the breakpoints (b)
+  delete ip=N      remove a breakpoint
+  watch R          stop when register R changes (w)
+  unwatch R        stop watching R
+  regs             show the registers, the instruction pointer and the output (r)
+  disasm           list the program, => marks the next instruction and * the breakpoints
+  analyze          show the loops, the dead code, which bits of A each output depends on and if part 2 can solve it
+  set R V          set register A, B, C or ip, V can be written as 0o1234, 0x29c or 0b101
+  lastwrite R [N]  find the instruction that last wrote register R, before output #N when N is given
+  export FILE      write every instruction run so far to FILE as JSON Lines
+  reset            start over with the registers from the input
+  quit             leave the debugger (q)
+`
+
+// Debugger runs a program on a Computer one command at a time, aoc vm reads the commands from the terminal
+//   - program: the program being debugged
+//   - initial: the registers A, B and C the program started with, reset goes back to them
+//   - breakpoints: instruction pointers to stop at
+//   - watches: registers to stop on when they change
+//   - trace: every instruction run since the last reset, back undoes them from the end. Changes made with set
+//     aren't in it, so stepping back past one puts the old values back
+type Debugger struct {
+	comp        *Computer
+	program     []int
+	initial     [3]int
+	breakpoints map[int]bool
+	watches     map[Register]bool
+	trace       *Trace
+	out         io.Writer
+}
+
+// NewDebugger loads the program with the registers set to a, b and c, everything it prints goes to out
+func NewDebugger(a, b, c int, program []int, out io.Writer) *Debugger {
+	d := &Debugger{
+		program:     program,
+		initial:     [3]int{a, b, c},
+		breakpoints: make(map[int]bool),
+		watches:     make(map[Register]bool),
+		out:         out,
+	}
+	d.reset()
+	return d
+}
+
+// Exec runs one command, see help for the list. It returns true once the command is quit
+func (d *Debugger) Exec(line string) (bool, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false, nil
+	}
+
+	command, args := fields[0], fields[1:]
+	switch command {
+	case "step", "s":
+		count, err := countArg(command, args)
+		if err != nil {
+			return false, err
+		}
+		return false, d.step(count)
+	case "back":
+		count, err := countArg(command, args)
+		if err != nil {
+			return false, err
+		}
+		return false, d.back(count)
+	case "continue", "c":
+		return false, d.cont()
+	case "break", "b":
+		if len(args) == 0 {
+			d.listBreakpoints()
+			return false, nil
+		}
+		ip, err := d.parseIP(args[0])
+		if err != nil {
+			return false, err
+		}
+		d.breakpoints[ip] = true
+		fmt.Fprintf(d.out, "Breakpoint at ip %d\n", ip)
+	case "delete":
+		if len(args) == 0 {
+			return false, fmt.Errorf("delete takes the breakpoint to remove, like ip=4")
+		}
+		ip, err := d.parseIP(args[0])
+		if err != nil {
+			return false, err
+		}
+		if !d.breakpoints[ip] {
+			return false, fmt.Errorf("no breakpoint at ip %d", ip)
+		}
+		delete(d.breakpoints, ip)
+	case "watch", "w", "unwatch":
+		if len(args) == 0 {
+			return false, fmt.Errorf("%s takes a register, A, B or C", command)
+		}
+		r, err := ParseRegister(args[0])
+		if err != nil {
+			return false, err
+		}
+		if command == "unwatch" {
+			delete(d.watches, r)
+		} else {
+			d.watches[r] = true
+			fmt.Fprintf(d.out, "Watching %s, now %d\n", r, d.comp.Register(r))
+		}
+	case "regs", "r":
+		d.regs()
+	case "disasm":
+		d.disasm()
+	case "analyze":
+		Analyze(d.program).Report(d.out)
+	case "set":
+		return false, d.set(args)
+	case "lastwrite":
+		return false, lastWrite(d.out, d.trace, args)
+	case "export":
+		return false, d.export(args)
+	case "reset":
+		d.reset()
+		d.where()
+	case "help", "h", "?":
+		fmt.Fprint(d.out, debuggerHelp)
+	case "quit", "q", "exit":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown command %q, try help", command)
+	}
+	return false, nil
+}
+
+func (d *Debugger) reset() {
+	d.comp = NewComputer(d.initial[0], d.initial[1], d.initial[2], false)
+	d.trace = &Trace{Events: make([]Event, 0)}
+}
+
+// step runs count instructions, printing each one with what it changed
+func (d *Debugger) step(count int) error {
+	for i := 0; i < count && !d.comp.Halted(d.program); i++ {
+		event, err := d.trace.step(d.comp, d.program)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(d.out, event)
+	}
+	d.where()
+	return nil
+}
+
+// back undoes count instructions, newest first
+func (d *Debugger) back(count int) error {
+	if len(d.trace.Events) == 0 {
+		return fmt.Errorf("no instructions have run since the start")
+	}
+	for i := 0; i < count; i++ {
+		event, ok := d.trace.undo(d.comp)
+		if !ok {
+			break
+		}
+		fmt.Fprintf(d.out, "undid %s\n", event)
+	}
+	d.where()
+	return nil
+}
+
+// cont runs until a breakpoint, a watched register changing or the end of the program. The instruction it starts
+// on always runs, so continuing from a breakpoint doesn't stop on it straight away
+func (d *Debugger) cont() error {
+	for i := 0; i < maxContinue; i++ {
+		if d.comp.Halted(d.program) {
+			d.where()
+			return nil
+		}
+		if i > 0 && d.breakpoints[d.comp.IP()] {
+			fmt.Fprintf(d.out, "Breakpoint at ip %d\n", d.comp.IP())
+			d.where()
+			return nil
+		}
+
+		event, err := d.trace.step(d.comp, d.program)
+		if err != nil {
+			return err
+		}
+		for r := A; r <= C; r++ {
+			if d.watches[r] && event.Before.Get(r) != event.After.Get(r) {
+				fmt.Fprintf(d.out, "%s changed from %d to %d at %s\n", r, event.Before.Get(r), event.After.Get(r), strings.TrimSpace(event.Instruction().String()))
+				d.where()
+				return nil
+			}
+		}
+	}
+	fmt.Fprintf(d.out, "Stopped after %d instructions without halting, continue to keep going\n", maxContinue)
+	d.where()
+	return nil
+}
+
+// where shows the next instruction with the values of the registers it reads, or that the program has halted
+func (d *Debugger) where() {
+	if d.comp.Halted(d.program) {
+		fmt.Fprintf(d.out, "Halted after %d instructions, output %s\n", len(d.trace.Events), strings.Join(d.comp.GetOutput(), ","))
+		return
+	}
+	instruction, _ := Decode(d.program, d.comp.IP())
+	fmt.Fprintf(d.out, "=> %s%s\n", instruction, d.operandValues(instruction))
+}
+
+// operandValues shows what the registers an instruction reads hold, "  [A = 729]"
+func (d *Debugger) operandValues(instruction Instruction) string {
+	read := make([]string, 0)
+	if r, ok := instruction.Reads(); ok {
+		read = append(read, fmt.Sprintf("%s = %d", r, d.comp.Register(r)))
+	}
+	switch instruction.Opcode {
+	case 0, 3, 6, 7:
+		if r, ok := instruction.Reads(); !ok || r != A {
+			read = append(read, fmt.Sprintf("A = %d", d.comp.Register(A)))
+		}
+	case 1:
+		read = append(read, fmt.Sprintf("B = %d", d.comp.Register(B)))
+	case 4:
+		read = append(read, fmt.Sprintf("B = %d", d.comp.Register(B)), fmt.Sprintf("C = %d", d.comp.Register(C)))
+	}
+	if len(read) == 0 {
+		return ""
+	}
+	return "  [" + strings.Join(read, ", ") + "]"
+}
+
+func (d *Debugger) regs() {
+	for r := A; r <= C; r++ {
+		fmt.Fprintf(d.out, "%s  %d (0o%o)\n", r, d.comp.Register(r), d.comp.Register(r))
+	}
+	fmt.Fprintf(d.out, "ip %d, %d instructions run\n", d.comp.IP(), len(d.trace.Events))
+	fmt.Fprintf(d.out, "output %s\n", strings.Join(d.comp.GetOutput(), ","))
+}
+
+func (d *Debugger) disasm() {
+	for _, instruction := range Disassemble(d.program) {
+		marker := "  "
+		if instruction.IP == d.comp.IP() && !d.comp.Halted(d.program) {
+			marker = "=>"
+		}
+		breakpoint := " "
+		if d.breakpoints[instruction.IP] {
+			breakpoint = "*"
+		}
+		fmt.Fprintf(d.out, "%s%s %s\n", marker, breakpoint, instruction)
+	}
+}
+
+func (d *Debugger) listBreakpoints() {
+	if len(d.breakpoints) == 0 {
+		fmt.Fprintln(d.out, "No breakpoints")
+		return
+	}
+	ips := make([]int, 0, len(d.breakpoints))
+	for ip := range d.breakpoints {
+		ips = append(ips, ip)
+	}
+	slices.Sort(ips)
+	for _, ip := range ips {
+		instruction, _ := Decode(d.program, ip)
+		fmt.Fprintf(d.out, "* %s\n", instruction)
+	}
+}
+
+// set changes a register or the instruction pointer
+func (d *Debugger) set(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("set takes a register and a value, like set A 0o1234")
+	}
+	value, err := strconv.ParseInt(args[1], 0, 64)
+	if err != nil || value < 0 {
+		return fmt.Errorf("%q isn't a number the registers can hold", args[1])
+	}
+
+	if strings.EqualFold(args[0], "ip") {
+		ip, err := d.parseIP(args[1])
+		if err != nil {
+			return err
+		}
+		d.comp.SetIP(ip)
+	} else {
+		r, err := ParseRegister(args[0])
+		if err != nil {
+			return err
+		}
+		d.comp.SetRegister(r, int(value))
+	}
+	d.where()
+	return nil
+}
+
+// parseIP reads an instruction pointer, written as ip=N or just N, that points at an instruction of the program
+func (d *Debugger) parseIP(text string) (int, error) {
+	ip, err := strconv.ParseInt(strings.TrimPrefix(strings.ToLower(text), "ip="), 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q isn't an instruction pointer, write it like ip=4", text)
+	}
+	if _, ok := Decode(d.program, int(ip)); !ok {
+		return 0, fmt.Errorf("there is no instruction at ip %d, the program is %d long", ip, len(d.program))
+	}
+	return int(ip), nil
+}
+
+// export writes the trace to a file as JSON Lines
+func (d *Debugger) export(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("export takes the file to write the trace to")
+	}
+	file, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	if err := d.trace.WriteJSONL(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(d.out, "Wrote %d instructions to %s\n", len(d.trace.Events), args[0])
+	return nil
+}
+
+// countArg reads the optional number of instructions of step and back
+func countArg(command string, args []string) (int, error) {
+	if len(args) == 0 {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%s takes a number of instructions, got %q", command, args[0])
+	}
+	return n, nil
+}
+
+// lastWrite answers the lastwrite command for the debugger and the viewer, which instruction last wrote a register,
+// either before output #N or before the end of the trace
+func lastWrite(out io.Writer, trace *Trace, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("lastwrite takes a register and optionally an output number, like lastwrite B 5")
+	}
+	r, err := ParseRegister(args[0])
+	if err != nil {
+		return err
+	}
+
+	step, before := len(trace.Events)+1, "so far"
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return fmt.Errorf("%q isn't an output number, they count from 1", args[1])
+		}
+		output, ok := trace.OutputEvent(n)
+		if !ok {
+			return fmt.Errorf("there is no output #%d in the trace", n)
+		}
+		step, before = output.Step, fmt.Sprintf("before output #%d (%s at step %d)", n, output.Output, output.Step)
+	}
+
+	event, ok := trace.LastWrite(r, step)
+	if !ok {
+		fmt.Fprintf(out, "Nothing wrote %s %s\n", r, before)
+		return nil
+	}
+	fmt.Fprintf(out, "%s was last written %s by\n%s\n", r, before, event)
+	return nil
+}
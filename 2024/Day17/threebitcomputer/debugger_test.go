@@ -0,0 +1,114 @@
+package threebitcomputer
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// session runs the commands and returns everything the debugger printed
+func session(t *testing.T, d *Debugger, commands ...string) string {
+	t.Helper()
+	var out strings.Builder
+	d.out = &out
+	for _, command := range commands {
+		if _, err := d.Exec(command); err != nil {
+			t.Fatalf("%s: %v", command, err)
+		}
+	}
+	return out.String()
+}
+
+func TestDebugger_Step(t *testing.T) {
+	d := NewDebugger(729, 0, 0, example, nil)
+	out := session(t, d, "step 3")
+	for _, want := range []string{"adv 1    A = A >> 1   A 729 -> 364", "out A    output A & 7   output 4", "jnz 0    jump to 0 if A != 0   jumps to 0", "=>  0: adv 1    A = A >> 1  [A = 364]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in\n%s", want, out)
+		}
+	}
+}
+
+func TestDebugger_Break(t *testing.T) {
+	d := NewDebugger(729, 0, 0, example, nil)
+	out := session(t, d, "break ip=4", "continue", "continue", "regs")
+	if strings.Count(out, "Breakpoint at ip 4") != 3 {
+		t.Errorf("Expected the breakpoint to be set and hit twice, got\n%s", out)
+	}
+	if !strings.Contains(out, "A  182 (0o266)") || !strings.Contains(out, "output 4,6") {
+		t.Errorf("Expected to stop after two outputs, got\n%s", out)
+	}
+
+	out = session(t, d, "delete ip=4", "c")
+	if !strings.Contains(out, "Halted after 30 instructions, output 4,6,3,5,6,3,5,2,1,0") {
+		t.Errorf("Expected the program to run to the end, got\n%s", out)
+	}
+}
+
+func TestDebugger_Watch(t *testing.T) {
+	// bst A, bxl 5, out B, adv 3, jnz 0
+	d := NewDebugger(0o17, 0, 0, []int{2, 4, 1, 5, 5, 5, 0, 3, 3, 0}, nil)
+	out := session(t, d, "watch B", "c", "c")
+	if !strings.Contains(out, "B changed from 0 to 7 at 0: bst A") || !strings.Contains(out, "B changed from 7 to 2 at 2: bxl 5") {
+		t.Errorf("Expected to stop on both writes to B, got\n%s", out)
+	}
+}
+
+func TestDebugger_SetAndReset(t *testing.T) {
+	d := NewDebugger(729, 0, 0, example, nil)
+	out := session(t, d, "set A 0o1234", "set ip 2", "r")
+	if !strings.Contains(out, "A  668 (0o1234)") || !strings.Contains(out, "ip 2") {
+		t.Errorf("Expected A and ip to be set, got\n%s", out)
+	}
+	out = session(t, d, "reset", "regs")
+	if !strings.Contains(out, "A  729") || !strings.Contains(out, "ip 0, 0 instructions run") {
+		t.Errorf("Expected reset to go back to the input, got\n%s", out)
+	}
+}
+
+func TestDebugger_Errors(t *testing.T) {
+	d := NewDebugger(729, 0, 0, example, nil)
+	for _, command := range []string{"jump", "break ip=5", "break ip=x", "set D 1", "set A -1", "step 0", "watch"} {
+		if _, err := d.Exec(command); err == nil {
+			t.Errorf("Expected an error for %q", command)
+		}
+	}
+	if quit, err := d.Exec("quit"); !quit || err != nil {
+		t.Errorf("Expected quit to quit, got %t, %v", quit, err)
+	}
+}
+
+func TestDebugger_Back(t *testing.T) {
+	d := NewDebugger(729, 0, 0, example, nil)
+	out := session(t, d, "step 5", "back 4", "regs")
+	if !strings.Contains(out, "undid #5") || !strings.Contains(out, "undid #2") || !strings.Contains(out, "A  364") || !strings.Contains(out, "ip 2, 1 instructions run\noutput \n") {
+		t.Errorf("Expected to go back to before the first output, got\n%s", out)
+	}
+	if out := session(t, d, "step"); !strings.Contains(out, "#2     2: out A") {
+		t.Errorf("Expected the output to run again as step 2, got\n%s", out)
+	}
+	session(t, d, "reset")
+	if _, err := d.Exec("back"); err == nil {
+		t.Errorf("Expected an error going back from the start")
+	}
+}
+
+func TestDebugger_LastWriteAndExport(t *testing.T) {
+	d := NewDebugger(729, 0, 0, example, nil)
+	out := session(t, d, "c", "lastwrite A 5", "lastwrite B")
+	if !strings.Contains(out, "A was last written before output #5 (6 at step 14) by\n#13") || !strings.Contains(out, "Nothing wrote B so far") {
+		t.Errorf("Expected lastwrite to find the adv before output #5, got\n%s", out)
+	}
+
+	file := t.TempDir() + "/trace.jsonl"
+	session(t, d, "export "+file)
+	exported, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer exported.Close()
+	trace, err := ReadTrace(exported)
+	if err != nil || len(trace.Events) != 30 {
+		t.Errorf("Expected the exported trace to have the 30 instructions, got %v", err)
+	}
+}
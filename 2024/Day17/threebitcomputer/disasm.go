@@ -0,0 +1,115 @@
+package threebitcomputer
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// mnemonics are the names of the opcodes, in opcode order
+var mnemonics = [8]string{"adv", "bxl", "bst", "jnz", "bxc", "out", "bdv", "cdv"}
+
+// Instruction is an opcode and its operand at a position in a program
+//   - IP: where the opcode is in the program
+//   - Opcode, Operand: the two 3-bit numbers of the instruction
+type Instruction struct {
+	IP      int
+	Opcode  int
+	Operand int
+}
+
+// Decode reads the instruction at ip, false if there is no full instruction there
+func Decode(program []int, ip int) (Instruction, bool) {
+	if ip < 0 || ip+1 >= len(program) {
+		return Instruction{}, false
+	}
+	return Instruction{IP: ip, Opcode: program[ip], Operand: program[ip+1]}, true
+}
+
+// Disassemble decodes the program the way it runs without jumps, an instruction at every even position
+func Disassemble(program []int) []Instruction {
+	instructions := make([]Instruction, 0, len(program)/2)
+	for ip := 0; ip+1 < len(program); ip += move {
+		instruction, _ := Decode(program, ip)
+		instructions = append(instructions, instruction)
+	}
+	return instructions
+}
+
+// Mnemonic is the name of the opcode, ??? for one that doesn't exist
+func (i Instruction) Mnemonic() string {
+	if i.Opcode < 0 || i.Opcode >= len(mnemonics) {
+		return "???"
+	}
+	return mnemonics[i.Opcode]
+}
+
+// Combo says if the operand is a combo operand, which reads a register for 4 to 6, rather than a literal
+func (i Instruction) Combo() bool {
+	switch i.Opcode {
+	case 0, 2, 5, 6, 7:
+		return true
+	default:
+		return false
+	}
+}
+
+// Reads returns the register a combo operand reads, false for literals and operands 0 to 3
+func (i Instruction) Reads() (Register, bool) {
+	if !i.Combo() || i.Operand < 4 || i.Operand > 6 {
+		return 0, false
+	}
+	return Register(i.Operand - 4), true
+}
+
+// Writes returns the register the instruction stores its result in, false for jnz and out
+func (i Instruction) Writes() (Register, bool) {
+	switch i.Opcode {
+	case 0:
+		return A, true
+	case 1, 2, 4, 6:
+		return B, true
+	case 7:
+		return C, true
+	default:
+		return 0, false
+	}
+}
+
+// OperandText is the operand as it reads in an assembly listing, the register name for combo operands 4 to 6.
+// The reserved combo operand 7 is shown as 7, which is what the computer uses for it
+func (i Instruction) OperandText() string {
+	if r, ok := i.Reads(); ok {
+		return r.String()
+	}
+	return strconv.Itoa(i.Operand)
+}
+
+// Effect describes what the instruction does, like "B = A & 7" for bst A
+func (i Instruction) Effect() string {
+	operand := i.OperandText()
+	switch i.Opcode {
+	case 0:
+		return "A = A >> " + operand
+	case 1:
+		return "B = B ^ " + operand
+	case 2:
+		return "B = " + operand + " & 7"
+	case 3:
+		return "jump to " + operand + " if A != 0"
+	case 4:
+		return "B = B ^ C"
+	case 5:
+		return "output " + operand + " & 7"
+	case 6:
+		return "B = A >> " + operand
+	case 7:
+		return "C = A >> " + operand
+	default:
+		return "unknown opcode"
+	}
+}
+
+// String formats the instruction for a listing, "2: bst A    B = A & 7"
+func (i Instruction) String() string {
+	return fmt.Sprintf("%2d: %s %-2s   %s", i.IP, i.Mnemonic(), i.OperandText(), i.Effect())
+}
@@ -0,0 +1,39 @@
+package threebitcomputer
+
+import (
+	"2024/util"
+	"fmt"
+	"strings"
+)
+
+// ParseInput reads the registers A, B and C and the program, which are separated by a blank line.
+// The program has to be pairs of 3-bit numbers so the computer never reads past its end or an unknown opcode
+func ParseInput(input []string) (int, int, int, []int, error) {
+	sections, err := util.Sections(input, 2)
+	if err != nil {
+		return 0, 0, 0, nil, err
+	}
+
+	registers, err := util.Ints(strings.Join(sections[0], " "))
+	if err != nil {
+		return 0, 0, 0, nil, err
+	}
+	if len(registers) != 3 {
+		return 0, 0, 0, nil, fmt.Errorf("found %d registers, expected 3", len(registers))
+	}
+
+	program, err := util.Ints(strings.Join(sections[1], " "))
+	if err != nil {
+		return 0, 0, 0, nil, err
+	}
+	if len(program) == 0 || len(program)%2 != 0 {
+		return 0, 0, 0, nil, fmt.Errorf("the program has %d values, expected pairs of opcode and operand", len(program))
+	}
+	for i, value := range program {
+		if value < 0 || value > 7 {
+			return 0, 0, 0, nil, fmt.Errorf("value %d of the program is %d, it has to be a 3-bit number", i, value)
+		}
+	}
+
+	return registers[0], registers[1], registers[2], program, nil
+}
@@ -0,0 +1,191 @@
+package threebitcomputer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Registers are the values of A, B and C at one point of a run
+type Registers struct {
+	A int `json:"a"`
+	B int `json:"b"`
+	C int `json:"c"`
+}
+
+// Get returns the value of a register
+func (r Registers) Get(register Register) int {
+	switch register {
+	case A:
+		return r.A
+	case B:
+		return r.B
+	default:
+		return r.C
+	}
+}
+
+// Event is one executed instruction
+//   - Step: how many instructions had run once this one was done, the first instruction is step 1
+//   - IP, Opcode, Operand: the instruction and where it was
+//   - Before, After: the registers around the instruction
+//   - NextIP: the instruction pointer afterwards
+//   - Output: the value the instruction output, empty when it wasn't an out
+//   - Halted: the program stopped after this instruction
+type Event struct {
+	Step    int       `json:"step"`
+	IP      int       `json:"ip"`
+	Opcode  int       `json:"opcode"`
+	Operand int       `json:"operand"`
+	Before  Registers `json:"before"`
+	After   Registers `json:"after"`
+	NextIP  int       `json:"next_ip"`
+	Output  string    `json:"output,omitempty"`
+	Halted  bool      `json:"halted,omitempty"`
+}
+
+// Instruction returns the instruction the event ran
+func (e Event) Instruction() Instruction {
+	return Instruction{IP: e.IP, Opcode: e.Opcode, Operand: e.Operand}
+}
+
+// Changes lists what the instruction did, "A 729 -> 364", "output 4" or "jumps to 0"
+func (e Event) Changes() []string {
+	changes := make([]string, 0)
+	for r := A; r <= C; r++ {
+		if before, after := e.Before.Get(r), e.After.Get(r); before != after {
+			changes = append(changes, fmt.Sprintf("%s %d -> %d", r, before, after))
+		}
+	}
+	if e.Output != "" {
+		changes = append(changes, "output "+e.Output)
+	}
+	if e.Opcode == 3 && !e.Halted {
+		changes = append(changes, fmt.Sprintf("jumps to %d", e.NextIP))
+	}
+	if e.Halted {
+		changes = append(changes, "halts")
+	}
+	return changes
+}
+
+// String formats the event as a line of a trace, the step, the instruction and its changes
+func (e Event) String() string {
+	line := fmt.Sprintf("#%-4d %s", e.Step, e.Instruction())
+	if changes := e.Changes(); len(changes) > 0 {
+		line += "   " + strings.Join(changes, ", ")
+	}
+	return line
+}
+
+// Trace is every instruction of a run in the order they ran
+type Trace struct {
+	Events []Event
+}
+
+// Record runs the program on the computer until it halts, keeping every instruction it runs. It stops with an
+// error after limit instructions, so a program that never halts still gives back what it did
+func Record(comp *Computer, program []int, limit int) (*Trace, error) {
+	trace := &Trace{Events: make([]Event, 0)}
+	for !comp.Halted(program) {
+		if len(trace.Events) == limit {
+			return trace, fmt.Errorf("the program didn't halt in %d instructions", limit)
+		}
+		if _, err := trace.step(comp, program); err != nil {
+			return trace, err
+		}
+	}
+	return trace, nil
+}
+
+// step runs one instruction on the computer and adds it to the trace
+func (t *Trace) step(comp *Computer, program []int) (Event, error) {
+	instruction, _ := Decode(program, comp.IP())
+	outputs := len(comp.GetOutput())
+	event := Event{IP: instruction.IP, Opcode: instruction.Opcode, Operand: instruction.Operand, Before: registersOf(comp)}
+	if err := comp.Step(program); err != nil {
+		return Event{}, err
+	}
+
+	event.Step = len(t.Events) + 1
+	event.After, event.NextIP, event.Halted = registersOf(comp), comp.IP(), comp.Halted(program)
+	if output := comp.GetOutput(); len(output) > outputs {
+		event.Output = output[len(output)-1]
+	}
+	t.Events = append(t.Events, event)
+	return event, nil
+}
+
+// undo takes the last event off the trace and puts the computer back the way it was before it
+func (t *Trace) undo(comp *Computer) (Event, bool) {
+	if len(t.Events) == 0 {
+		return Event{}, false
+	}
+	event := t.Events[len(t.Events)-1]
+	t.Events = t.Events[:len(t.Events)-1]
+
+	comp.a, comp.b, comp.c = event.Before.A, event.Before.B, event.Before.C
+	comp.SetIP(event.IP)
+	if event.Output != "" {
+		comp.outResults = comp.outResults[:len(comp.outResults)-1]
+	}
+	return event, true
+}
+
+func registersOf(comp *Computer) Registers {
+	return Registers{A: comp.Register(A), B: comp.Register(B), C: comp.Register(C)}
+}
+
+// OutputEvent returns the event that output value n, counting from 1
+func (t *Trace) OutputEvent(n int) (Event, bool) {
+	for _, event := range t.Events {
+		if event.Output == "" {
+			continue
+		}
+		if n--; n == 0 {
+			return event, true
+		}
+	}
+	return Event{}, false
+}
+
+// LastWrite returns the last event before step that stored a value in the register, even one it already held
+func (t *Trace) LastWrite(r Register, step int) (Event, bool) {
+	for i := min(step-1, len(t.Events)) - 1; i >= 0; i-- {
+		if written, ok := t.Events[i].Instruction().Writes(); ok && written == r {
+			return t.Events[i], true
+		}
+	}
+	return Event{}, false
+}
+
+// WriteJSONL writes the trace as JSON Lines, one event per line
+func (t *Trace) WriteJSONL(w io.Writer) error {
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+	for _, event := range t.Events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return buffered.Flush()
+}
+
+// ReadTrace reads a trace written with WriteJSONL
+func ReadTrace(r io.Reader) (*Trace, error) {
+	trace := &Trace{Events: make([]Event, 0)}
+	decoder := json.NewDecoder(r)
+	for decoder.More() {
+		var event Event
+		if err := decoder.Decode(&event); err != nil {
+			return nil, fmt.Errorf("invalid event after step %d: %w", len(trace.Events), err)
+		}
+		if event.Step != len(trace.Events)+1 {
+			return nil, fmt.Errorf("expected step %d, found step %d", len(trace.Events)+1, event.Step)
+		}
+		trace.Events = append(trace.Events, event)
+	}
+	return trace, nil
+}
@@ -0,0 +1,100 @@
+package threebitcomputer
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// record records the example with A = 729
+func record(t *testing.T) *Trace {
+	t.Helper()
+	trace, err := Record(NewComputer(729, 0, 0, false), example, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return trace
+}
+
+func TestRecord(t *testing.T) {
+	trace := record(t)
+	if len(trace.Events) != 30 {
+		t.Fatalf("Expected 30 instructions, got %d", len(trace.Events))
+	}
+	first := Event{Step: 1, IP: 0, Opcode: 0, Operand: 1, Before: Registers{A: 729}, After: Registers{A: 364}, NextIP: 2}
+	if trace.Events[0] != first {
+		t.Errorf("Expected %+v, got %+v", first, trace.Events[0])
+	}
+	if last := trace.Events[29]; !last.Halted || last.Opcode != 3 || last.After.A != 0 {
+		t.Errorf("Expected the last instruction to be the jnz that halts, got %+v", last)
+	}
+
+	_, err := Record(NewComputer(729, 0, 0, false), []int{3, 0}, 100)
+	if err == nil {
+		t.Errorf("Expected an error for a program that doesn't halt")
+	}
+}
+
+func TestTrace_OutputEventAndLastWrite(t *testing.T) {
+	trace := record(t)
+	event, ok := trace.OutputEvent(5)
+	if !ok || event.Step != 14 || event.Output != "6" {
+		t.Fatalf("Expected output #5 to be 6 at step 14, got %+v, %t", event, ok)
+	}
+	if _, ok := trace.OutputEvent(11); ok {
+		t.Errorf("Expected no output #11")
+	}
+
+	write, ok := trace.LastWrite(A, event.Step)
+	if !ok || write.Step != 13 || write.After.A != 22 {
+		t.Errorf("Expected A to be last written to 22 at step 13, got %+v, %t", write, ok)
+	}
+	if _, ok := trace.LastWrite(B, event.Step); ok {
+		t.Errorf("Expected nothing to write B")
+	}
+}
+
+func TestTrace_JSONL(t *testing.T) {
+	trace := record(t)
+	var buffer bytes.Buffer
+	if err := trace.WriteJSONL(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(buffer.String(), "\n"); lines != len(trace.Events) {
+		t.Errorf("Expected a line per instruction, got %d", lines)
+	}
+	read, err := ReadTrace(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, trace) {
+		t.Errorf("Expected the trace to read back the same")
+	}
+
+	for _, invalid := range []string{`{"step": 2}`, `{"step": 1`, `[1]`} {
+		if _, err := ReadTrace(strings.NewReader(invalid)); err == nil {
+			t.Errorf("Expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestViewer(t *testing.T) {
+	var out strings.Builder
+	v := NewViewer(record(t), &out)
+	for _, command := range []string{"output 5", "regs", "back 2", "regs", "goto 30", "step"} {
+		if _, err := v.Exec(command); err != nil {
+			t.Fatalf("%s: %v", command, err)
+		}
+	}
+	for _, want := range []string{"#14    2: out A", "A  22 (0o26)\nB  0 (0o0)\nC  0 (0o0)\nstep 14 of 30\noutput 4,6,3,5,6\n", "undid #13", "step 12 of 30\noutput 4,6,3,5\n", "End of the trace after 30 instructions, output 4,6,3,5,6,3,5,2,1,0"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in\n%s", want, out.String())
+		}
+	}
+	for _, command := range []string{"goto 31", "output 0", "lastwrite D"} {
+		if _, err := v.Exec(command); err == nil {
+			t.Errorf("Expected an error for %q", command)
+		}
+	}
+}
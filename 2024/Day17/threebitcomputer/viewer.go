@@ -0,0 +1,136 @@
+package threebitcomputer
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const viewerHelp = `Commands:
+  step [n]         go forward n instructions, 1 by default (s)
+  back [n]         go back n instructions, 1 by default
+  goto N           go to just after step N, goto 0 is the start
+  output N         go to just after the instruction that printed output #N
+  lastwrite R [N]  find the instruction that last wrote register R, before output #N when N is given
+  regs             show the registers and the output at this point (r)
+  quit             leave the viewer (q)
+`
+
+// Viewer moves through a recorded trace in either direction, like a Debugger that can't change the run
+//   - pos: how many events have happened at the point being viewed, 0 is before the first instruction
+type Viewer struct {
+	trace *Trace
+	pos   int
+	out   io.Writer
+}
+
+// NewViewer opens the trace at its start, everything it prints goes to out
+func NewViewer(trace *Trace, out io.Writer) *Viewer {
+	return &Viewer{trace: trace, out: out}
+}
+
+// Exec runs one command, see help for the list. It returns true once the command is quit
+func (v *Viewer) Exec(line string) (bool, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false, nil
+	}
+
+	command, args := fields[0], fields[1:]
+	switch command {
+	case "step", "s", "back":
+		count, err := countArg(command, args)
+		if err != nil {
+			return false, err
+		}
+		if command == "back" {
+			for i := 0; i < count && v.pos > 0; i++ {
+				fmt.Fprintf(v.out, "undid %s\n", v.trace.Events[v.pos-1])
+				v.pos--
+			}
+		} else {
+			for i := 0; i < count && v.pos < len(v.trace.Events); i++ {
+				fmt.Fprintln(v.out, v.trace.Events[v.pos])
+				v.pos++
+			}
+		}
+		v.where()
+	case "goto":
+		if len(args) != 1 {
+			return false, fmt.Errorf("goto takes a step number")
+		}
+		step, err := strconv.Atoi(args[0])
+		if err != nil || step < 0 || step > len(v.trace.Events) {
+			return false, fmt.Errorf("%q isn't a step of the trace, it has %d", args[0], len(v.trace.Events))
+		}
+		v.pos = step
+		v.where()
+	case "output":
+		if len(args) != 1 {
+			return false, fmt.Errorf("output takes an output number, they count from 1")
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return false, fmt.Errorf("%q isn't an output number", args[0])
+		}
+		event, ok := v.trace.OutputEvent(n)
+		if !ok {
+			return false, fmt.Errorf("there is no output #%d in the trace", n)
+		}
+		v.pos = event.Step
+		fmt.Fprintln(v.out, event)
+		v.where()
+	case "lastwrite":
+		return false, lastWrite(v.out, v.trace, args)
+	case "regs", "r":
+		v.regs()
+	case "help", "h", "?":
+		fmt.Fprint(v.out, viewerHelp)
+	case "quit", "q", "exit":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown command %q, try help", command)
+	}
+	return false, nil
+}
+
+// registers returns the registers at the point being viewed
+func (v *Viewer) registers() Registers {
+	if v.pos == 0 {
+		if len(v.trace.Events) == 0 {
+			return Registers{}
+		}
+		return v.trace.Events[0].Before
+	}
+	return v.trace.Events[v.pos-1].After
+}
+
+// output returns the values output up to the point being viewed
+func (v *Viewer) output() []string {
+	output := make([]string, 0)
+	for _, event := range v.trace.Events[:v.pos] {
+		if event.Output != "" {
+			output = append(output, event.Output)
+		}
+	}
+	return output
+}
+
+// where shows the next instruction in the trace, or that the trace has ended
+func (v *Viewer) where() {
+	if v.pos == len(v.trace.Events) {
+		fmt.Fprintf(v.out, "End of the trace after %d instructions, output %s\n", v.pos, strings.Join(v.output(), ","))
+		return
+	}
+	fmt.Fprintf(v.out, "=> %s\n", v.trace.Events[v.pos].Instruction())
+}
+
+func (v *Viewer) regs() {
+	registers := v.registers()
+	for r := A; r <= C; r++ {
+		fmt.Fprintf(v.out, "%s  %d (0o%o)\n", r, registers.Get(r), registers.Get(r))
+	}
+	fmt.Fprintf(v.out, "step %d of %d\n", v.pos, len(v.trace.Events))
+	fmt.Fprintf(v.out, "output %s\n", strings.Join(v.output(), ","))
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"2024/Day18/fallingbytes"
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/gen"
+	"2024/puzzle"
+	"fmt"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 18)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(analyzer(b, input), part1Bytes)
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 18)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		coordinates, err := parseCoordinates(input)
+		if err != nil {
+			b.Fatal(err)
+		}
+		part2(analyzer(b, input), coordinates)
+	}
+}
+
+func analyzer(tb testing.TB, input []string) *fallingbytes.Analyzer {
+	coordinates, err := parseCoordinates(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	a, err := fallingbytes.NewAnalyzer(memorySize, memorySize, coordinates)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return a
+}
+
+func TestProperty_Blocking(t *testing.T) {
+	const size = 15
+	for seed := uint64(0); seed < 100; seed++ {
+		drops := gen.NewByteDrops(gen.New(seed), size, size, 150)
+		coordinates, err := parseCoordinates(drops.Lines)
+		if err != nil {
+			t.Fatalf("Seed %d: %v", seed, err)
+		}
+		a, err := fallingbytes.NewAnalyzer(size, size, coordinates)
+		if err != nil {
+			t.Fatalf("Seed %d: %v", seed, err)
+		}
+
+		if steps := a.ShortestPath(0); steps != 2*size-2 {
+			t.Fatalf("Seed %d: expected %d steps with no bytes, got %d", seed, 2*size-2, steps)
+		}
+
+		// The path only gets longer as bytes fall, until it is cut off for good
+		timeline := a.Timeline()
+		for i := 1; i < len(timeline); i++ {
+			if timeline[i-1] == -1 && timeline[i] != -1 || timeline[i] != -1 && timeline[i] < timeline[i-1] {
+				t.Fatalf("Seed %d: the path goes from %d to %d steps when byte %d falls", seed, timeline[i-1], timeline[i], i-1)
+			}
+		}
+
+		blocking, ok := a.FirstBlocking()
+		searched, searchedOk := a.FirstBlockingSearch()
+		if blocking != searched || ok != searchedOk {
+			t.Fatalf("Seed %d: union-find says %d %t, binary search says %d %t", seed, blocking, ok, searched, searchedOk)
+		}
+		if !ok {
+			continue
+		}
+		if a.ShortestPath(blocking) == -1 || a.ShortestPath(blocking+1) != -1 {
+			t.Fatalf("Seed %d: byte %d is not the one that cuts off the exit", seed, blocking)
+		}
+		answer, _ := part2(a, coordinates)
+		if want := fmt.Sprintf("%d,%d", drops.Bytes[blocking][0], drops.Bytes[blocking][1]); answer != want {
+			t.Fatalf("Seed %d: expected %s, got %v", seed, want, answer)
+		}
+	}
+}
+
+func FuzzParseCoordinates(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		coordinates, err := parseCoordinates(lines)
+		if err == nil && len(coordinates) != len(lines) {
+			t.Errorf("Expected a coordinate per line, got %d for %d lines", len(coordinates), len(lines))
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		// the example memory space is 7 by 7, and part 1 lets the first 12 bytes fall
+		coordinates, err := parseCoordinates(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, err := fallingbytes.NewAnalyzer(7, 7, coordinates)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if example.Part == 2 {
+			answer, _ := part2(a, coordinates)
+			return answer
+		}
+		return part1(a, 12)
+	})
+}
@@ -0,0 +1,222 @@
+package fallingbytes
+
+import (
+	"2024/util"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	direction = [][2]int{{0, 1}, {0, -1}, {1, 0}, {-1, 0}}
+	// surrounding includes diagonals, bytes touching at a corner still form a wall
+	surrounding = [][2]int{{0, 1}, {0, -1}, {1, 0}, {-1, 0}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+)
+
+// never is the index of the byte that lands on a cell no byte falls on, so the cell stays free however many fall
+const never = math.MaxInt
+
+// Analyzer answers questions about a memory space as bytes fall into it one at a time.
+// The path always goes from the top left corner to the bottom right corner
+type Analyzer struct {
+	width, height int
+	bytes         [][2]int
+	// fallen[cell] is the index of the first byte that lands on the cell, or never if none does
+	fallen []int
+}
+
+// NewAnalyzer creates an Analyzer for a width x height memory space. bytes holds the x, y coordinates of every byte in
+// the order they fall
+func NewAnalyzer(width, height int, bytes [][2]int) (*Analyzer, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid memory size %dx%d", width, height)
+	}
+
+	a := &Analyzer{width: width, height: height, bytes: bytes, fallen: make([]int, width*height)}
+	for cell := range a.fallen {
+		a.fallen[cell] = never
+	}
+	for i, b := range bytes {
+		if !a.inBounds(b) {
+			return nil, fmt.Errorf("byte %d at %v is outside of the %dx%d memory space", i, b, width, height)
+		}
+		if cell := a.index(b); a.fallen[cell] == never {
+			a.fallen[cell] = i
+		}
+	}
+	return a, nil
+}
+
+// ShortestPath finds the minimum number of steps from start to exit once the first count bytes have fallen.
+// Returns -1 if the exit is not reachable
+func (a *Analyzer) ShortestPath(count int) int {
+	steps, _ := a.bfs(count)
+	return steps
+}
+
+// Timeline returns the shortest path length after each number of fallen bytes, from 0 up to all of them.
+// The search only reruns when a byte lands on the current shortest path, since any other byte can't make it longer
+func (a *Analyzer) Timeline() []int {
+	timeline := make([]int, len(a.bytes)+1)
+	steps, path := a.bfs(0)
+	timeline[0] = steps
+
+	for count := 1; count <= len(a.bytes); count++ {
+		if steps != -1 && path[a.index(a.bytes[count-1])] {
+			steps, path = a.bfs(count)
+		}
+		timeline[count] = steps
+	}
+	return timeline
+}
+
+// FirstBlocking finds the index of the first byte that cuts the start off from the exit.
+//
+// It works backwards with union-find: starting with every byte fallen, the free cells are joined with their free
+// neighbors, then bytes are lifted in reverse order. The first byte whose removal connects start and exit is the
+// one that blocked the path. Returns false if the path is never blocked
+func (a *Analyzer) FirstBlocking() (int, bool) {
+	sets := util.NewUnionFind(a.width * a.height)
+	start, exit := 0, a.width*a.height-1
+
+	free := func(cell int, count int) bool {
+		return a.fallen[cell] >= count
+	}
+	join := func(cell int, count int) {
+		x, y := cell%a.width, cell/a.width
+		for _, dir := range direction {
+			neighbor := [2]int{x + dir[0], y + dir[1]}
+			if a.inBounds(neighbor) && free(a.index(neighbor), count) {
+				sets.Union(cell, a.index(neighbor))
+			}
+		}
+	}
+
+	for cell := range a.fallen {
+		if free(cell, len(a.bytes)) {
+			join(cell, len(a.bytes))
+		}
+	}
+	if free(start, len(a.bytes)) && free(exit, len(a.bytes)) && sets.Find(start) == sets.Find(exit) {
+		return -1, false
+	}
+
+	for count := len(a.bytes) - 1; count >= 0; count-- {
+		cell := a.index(a.bytes[count])
+		if a.fallen[cell] != count {
+			// a byte already landed here earlier, the cell stays blocked
+			continue
+		}
+		join(cell, count)
+		if free(start, count) && free(exit, count) && sets.Find(start) == sets.Find(exit) {
+			return count, true
+		}
+	}
+	return -1, false
+}
+
+// FirstBlockingSearch finds the same byte as FirstBlocking with a binary search over the number of fallen bytes,
+// running a BFS for every probe
+func (a *Analyzer) FirstBlockingSearch() (int, bool) {
+	count := sort.Search(len(a.bytes)+1, func(count int) bool {
+		return a.ShortestPath(count) == -1
+	})
+	if count > len(a.bytes) || count == 0 {
+		return -1, false
+	}
+	return count - 1, true
+}
+
+// Cut returns the wall of fallen bytes that separates the start from the exit once the first blocking byte lands:
+// every byte connected to the blocking byte, counting diagonals, in the order they fell. Returns nil if the path is never blocked
+func (a *Analyzer) Cut() [][2]int {
+	blocking, ok := a.FirstBlocking()
+	if !ok {
+		return nil
+	}
+
+	seen := map[int]bool{a.index(a.bytes[blocking]): true}
+	queue := []int{a.index(a.bytes[blocking])}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		x, y := cell%a.width, cell/a.width
+		for _, dir := range surrounding {
+			neighbor := [2]int{x + dir[0], y + dir[1]}
+			if !a.inBounds(neighbor) {
+				continue
+			}
+			if next := a.index(neighbor); !seen[next] && a.fallen[next] <= blocking {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	cells := make([]int, 0, len(seen))
+	for cell := range seen {
+		cells = append(cells, cell)
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		return a.fallen[cells[i]] < a.fallen[cells[j]]
+	})
+
+	cut := make([][2]int, 0, len(cells))
+	for _, cell := range cells {
+		cut = append(cut, [2]int{cell % a.width, cell / a.width})
+	}
+	return cut
+}
+
+// bfs performs a breadth-first search from start to exit with the first count bytes fallen.
+// Returns the number of steps (-1 if unreachable) and the cells on the path that was found
+func (a *Analyzer) bfs(count int) (int, []bool) {
+	start, exit := 0, a.width*a.height-1
+	if a.fallen[start] < count || a.fallen[exit] < count {
+		return -1, nil
+	}
+
+	parent := make([]int, a.width*a.height)
+	for cell := range parent {
+		parent[cell] = -1
+	}
+	parent[start] = start
+	queue := []int{start}
+
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+
+		if cell == exit {
+			path := make([]bool, a.width*a.height)
+			steps := 0
+			for ; cell != start; cell = parent[cell] {
+				path[cell] = true
+				steps++
+			}
+			path[start] = true
+			return steps, path
+		}
+
+		x, y := cell%a.width, cell/a.width
+		for _, dir := range direction {
+			neighbor := [2]int{x + dir[0], y + dir[1]}
+			if !a.inBounds(neighbor) {
+				continue
+			}
+			if next := a.index(neighbor); parent[next] == -1 && a.fallen[next] >= count {
+				parent[next] = cell
+				queue = append(queue, next)
+			}
+		}
+	}
+	return -1, nil
+}
+
+func (a *Analyzer) index(b [2]int) int {
+	return b[1]*a.width + b[0]
+}
+
+func (a *Analyzer) inBounds(b [2]int) bool {
+	return b[0] >= 0 && b[0] < a.width && b[1] >= 0 && b[1] < a.height
+}
@@ -0,0 +1,89 @@
+package fallingbytes
+
+import (
+	"math/rand"
+	"testing"
+)
+
+var example = [][2]int{
+	{5, 4}, {4, 2}, {4, 5}, {3, 0}, {2, 1}, {6, 3}, {2, 4}, {1, 5}, {0, 6}, {3, 3}, {2, 6}, {5, 1}, {1, 2},
+	{5, 5}, {2, 5}, {6, 5}, {1, 4}, {0, 4}, {6, 4}, {1, 1}, {6, 1}, {1, 0}, {0, 5}, {1, 6}, {2, 0},
+}
+
+func TestAnalyzer_Example(t *testing.T) {
+	analyzer, err := NewAnalyzer(7, 7, example)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := analyzer.ShortestPath(12); got != 22 {
+		t.Errorf("Expected 22 steps after 12 bytes, got %d", got)
+	}
+
+	blocking, ok := analyzer.FirstBlocking()
+	if !ok || example[blocking] != [2]int{6, 1} {
+		t.Errorf("Expected byte 6,1 to block the exit, got %d (%v)", blocking, ok)
+	}
+
+	cut := analyzer.Cut()
+	if len(cut) == 0 || cut[len(cut)-1] != [2]int{6, 1} {
+		t.Errorf("Expected the cut to end with the blocking byte, got %v", cut)
+	}
+}
+
+// TestAnalyzer_Random checks union-find, binary search and the timeline all agree with a plain BFS
+func TestAnalyzer_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(18))
+	for n := 0; n < 200; n++ {
+		width, height := 2+r.Intn(8), 2+r.Intn(8)
+		bytes := make([][2]int, r.Intn(width*height))
+		for i := range bytes {
+			bytes[i] = [2]int{r.Intn(width), r.Intn(height)}
+		}
+
+		analyzer, err := NewAnalyzer(width, height, bytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		timeline := analyzer.Timeline()
+		expected := -1
+		for count := 0; count <= len(bytes); count++ {
+			steps := analyzer.ShortestPath(count)
+			if timeline[count] != steps {
+				t.Fatalf("timeline after %d bytes: expected %d, got %d", count, steps, timeline[count])
+			}
+			if steps == -1 && expected == -1 && count > 0 {
+				expected = count - 1
+			}
+		}
+
+		blocking, ok := analyzer.FirstBlocking()
+		searched, searchOk := analyzer.FirstBlockingSearch()
+		if ok != (expected != -1) || (ok && blocking != expected) {
+			t.Fatalf("union-find: expected %d, got %d (%v)", expected, blocking, ok)
+		}
+		if searchOk != ok || searched != blocking {
+			t.Fatalf("binary search: expected %d, got %d (%v)", blocking, searched, searchOk)
+		}
+	}
+}
+
+func TestNewAnalyzer_OutOfBounds(t *testing.T) {
+	if _, err := NewAnalyzer(3, 3, [][2]int{{3, 0}}); err == nil {
+		t.Error("Expected error for a byte outside of the memory space")
+	}
+}
+
+func TestAnalyzer_MoreThanFallen(t *testing.T) {
+	analyzer, err := NewAnalyzer(7, 7, [][2]int{{1, 0}, {3, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// asking for more bytes than there are only lets the ones there are fall
+	for _, count := range []int{2, 3, 1024} {
+		if got := analyzer.ShortestPath(count); got != 12 {
+			t.Errorf("Expected 12 steps after %d bytes, got %d", count, got)
+		}
+	}
+}
@@ -1,86 +1,49 @@
 package main
 
 import (
+	"2024/parallel"
+	"2024/solution"
 	"2024/util"
+	"context"
 	"fmt"
+	"log/slog"
+	"os"
 	"regexp"
 	"slices"
-	"sync"
 )
 
 const (
 	pattern = `([a-zA-Z]+)`
-	workers = 10
 )
 
-type Task struct {
-	word string
-}
-
 func main() {
-	input := util.ReadInput(util.Parameter())
-	splitIndex := slices.Index(input, "")
-	wordBank, words := createWordBank(input[:splitIndex]), input[splitIndex+1:]
-
-	part1(wordBank, words)
-	part2(wordBank, words)
-}
-
-func part2(bank []string, words []string) {
-	tasks := make(chan Task)
-	results := make(chan int, len(words))
-	var wg sync.WaitGroup
-
-	wg.Add(workers)
-	for i := 0; i < workers; i++ {
-		go worker(tasks, results, &wg, bank, longestWord(bank), false)
+	wordBank, words, err := parseTowels(util.ReadInput(util.Parameter()))
+	if err != nil {
+		slog.Error("Unable to read the towels", "err", err)
+		os.Exit(1)
 	}
 
-	for _, word := range words {
-		tasks <- Task{word}
-	}
-	close(tasks)
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
+	sol := solution.New(19)
+	sol.Part(1, func() any { return part1(wordBank, words) })
+	sol.Part(2, func() any { return part2(wordBank, words) })
+}
 
-	count := 0
-	for result := range results {
-		count += result
-	}
+func part2(bank []string, words []string) int {
+	count, _ := parallel.MapReduce(context.Background(), words, func(_ context.Context, word string) (int, error) {
+		return combinations(word, bank), nil
+	}, 0, parallel.Sum[int])
 
-	fmt.Println("(part 2) Ans:", count)
+	return count
 }
 
-func part1(bank []string, words []string) {
-	tasks := make(chan Task)
-	results := make(chan int, len(words))
-	var wg sync.WaitGroup
+func part1(bank []string, words []string) int {
 	longestWordInBank := longestWord(bank)
 
-	wg.Add(workers)
-	for i := 0; i < workers; i++ {
-		go worker(tasks, results, &wg, bank, longestWordInBank, true)
-	}
-
-	for _, word := range words {
-		tasks <- Task{word}
-	}
-	close(tasks)
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	count := 0
-	for result := range results {
-		count += result
-	}
+	count, _ := parallel.MapReduce(context.Background(), words, func(_ context.Context, word string) (int, error) {
+		return wordBreak(word, bank, longestWordInBank), nil
+	}, 0, parallel.Sum[int])
 
-	fmt.Println("(part 1) Ans:", count)
+	return count
 }
 
 func longestWord(bank []string) int {
@@ -91,6 +54,23 @@ func longestWord(bank []string) int {
 	return longest
 }
 
+// parseTowels reads the towel patterns from the first line and the designs after the blank line
+func parseTowels(input []string) ([]string, []string, error) {
+	sections, err := util.Sections(input, 2)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sections[0]) != 1 {
+		return nil, nil, fmt.Errorf("the towel patterns take %d lines, expected 1", len(sections[0]))
+	}
+
+	bank := createWordBank(sections[0])
+	if len(bank) == 0 {
+		return nil, nil, fmt.Errorf("no towel patterns found in %q", sections[0][0])
+	}
+	return bank, sections[1], nil
+}
+
 func createWordBank(strings []string) []string {
 	reg := regexp.MustCompile(pattern)
 	toReturn := make([]string, 0)
@@ -154,14 +134,3 @@ func combinations(word string, bank []string) int {
 
 	return dp[len(word)]
 }
-
-func worker(tasks <-chan Task, results chan<- int, group *sync.WaitGroup, wordBank []string, longestWord int, partOne bool) {
-	defer group.Done()
-	for task := range tasks {
-		if partOne {
-			results <- wordBreak(task.word, wordBank, longestWord)
-		} else {
-			results <- combinations(task.word, wordBank)
-		}
-	}
-}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 19)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(towels(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 19)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(towels(b, input))
+	}
+}
+
+func towels(tb testing.TB, input []string) ([]string, []string) {
+	bank, designs, err := parseTowels(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return bank, designs
+}
+
+func FuzzParseTowels(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		bank, designs, err := parseTowels(lines)
+		if err != nil {
+			return
+		}
+		// blank lines only separate the sections, the patterns are on the first line left and a design on every other
+		written := make([]string, 0, len(lines))
+		for _, line := range lines {
+			if strings.TrimSpace(line) != "" {
+				written = append(written, line)
+			}
+		}
+		for _, towel := range bank {
+			if towel == "" || !strings.Contains(written[0], towel) {
+				t.Fatalf("Expected towel %q to be one of the patterns on %q", towel, written[0])
+			}
+		}
+		if !slices.Equal(designs, written[1:]) {
+			t.Fatalf("Expected the designs %q, got %q", written[1:], designs)
+		}
+		// a design can be made exactly when there is at least one way to make it, the count of ways overflows on long
+		// designs so only the short ones are compared
+		longest := longestWord(bank)
+		for _, design := range designs {
+			if len(design) > 40 {
+				continue
+			}
+			if possible, ways := wordBreak(design, bank, longest), combinations(design, bank); (possible == 1) != (ways > 0) {
+				t.Fatalf("Expected %q to be possible exactly when it has a way to be made, got %d and %d ways", design, possible, ways)
+			}
+		}
+		if possible := part1(bank, designs); possible > len(designs) {
+			t.Errorf("Expected at most %d possible designs, got %d", len(designs), possible)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		if example.Part == 2 {
+			return part2(towels(t, input))
+		}
+		return part1(towels(t, input))
+	})
+}
@@ -1,189 +1,80 @@
 package main
 
 import (
-	"bufio"
+	"2024/Day2/reports"
+	"2024/solution"
+	"2024/util"
 	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
-	"sync"
 )
 
 /*
 	Advent of Code 2024 Day 2 Solutions
-	Kept the same theme of trying to solve these concurrently. I felt like I did a good job with part 1, it makes sense.
-	However, once I saw part 2 I realized that doing it concurrently might not be the best approach, seeing as you had
-	to search for a possible permutation where the int slice would become valid. One concern would be too many goroutines
-	being produced. I think this could have been simplified if I didn't use wait groups in part 2, however because
-	checkSafetyLevels required a wait group I feel like I was forced to use them.
+	Originally kept the same theme of trying to solve these concurrently, but part 2 ended up starting a goroutine for every report
+	and another for every removed level. The checks now live in the reports package, which finds the levels that need to be
+	dropped in a single pass over the report instead of trying every permutation.
 */
 
 func main() {
-	filename := "2024/Day2/day2Data.txt"
-	//filename := "2024/Day2/example_data.txt"
-	data := convertData(readInData(filename))
-	part1(data)
-	part2(data)
-
-}
-
-// readInData takes a file and reads each line in
-func readInData(filename string) []string {
-	fmt.Println("Reading file ", filename)
-	data, _ := os.Open(filename)
-	scanner := bufio.NewScanner(data)
-	scanner.Split(bufio.ScanLines)
-	var fileLines []string
-	for scanner.Scan() {
-		fileLines = append(fileLines, scanner.Text())
+	data, err := convertData(util.ReadInput(util.Parameter()))
+	if err != nil {
+		slog.Error("Unable to read the reports", "err", err)
+		os.Exit(1)
 	}
-	data.Close()
-	return fileLines
+	sol := solution.New(2)
+	sol.Part(1, func() any { return part1(data) })
+	sol.Part(2, func() any { return part2(data) })
 }
 
 // convertData takes in the raw data and turns it into a slice of int slices
-// a list of reports
-func convertData(rawData []string) [][]int {
-	fmt.Println("Converting data ")
+// a list of reports, every report needs at least one level
+func convertData(rawData []string) ([][]int, error) {
+	slog.Debug("Converting data")
 	toReturn := make([][]int, 0)
-	for _, line := range rawData {
+	for i, line := range rawData {
 		row := make([]int, 0)
 		for _, number := range strings.Fields(line) {
-			convertedNumber, _ := strconv.Atoi(number)
+			convertedNumber, err := strconv.Atoi(number)
+			if err != nil {
+				return nil, fmt.Errorf("report %d: %w", i+1, err)
+			}
 			row = append(row, convertedNumber)
 		}
+		if len(row) == 0 {
+			return nil, fmt.Errorf("report %d has no levels", i+1)
+		}
 		toReturn = append(toReturn, row)
 	}
-	return toReturn
+	return toReturn, nil
 }
 
 // part1 Requirements:
 // Must be all increasing or decreasing
 // difference between adjacent levels are considered safe if differ by at least 1 or at most 3
-func part1(lines [][]int) {
-	safeChannel := make(chan bool)
-	var wg sync.WaitGroup
-
-	for _, line := range lines {
-		wg.Add(1)
-		go checkSafetyLevels(line, safeChannel, &wg)
-	}
-
-	go func() {
-		wg.Wait()
-		close(safeChannel)
-	}()
-
-	safeReports := 0
-	for safeReport := range safeChannel {
-		if safeReport {
-			safeReports++
-		}
-	}
-
-	fmt.Println("(part1) Safe reports found: ", safeReports)
+func part1(lines [][]int) int {
+	tolerance := reports.DefaultTolerance
+	tolerance.Dampeners = 0
+	return countSafeReports(lines, reports.NewChecker(tolerance))
 }
 
 // part2 Requirements:
 // Must be all increasing or decreasing
 // difference between adjacent levels are considered safe if differ by at least 1 or at most 3
 // can skip one bad level in each report
-func part2(lines [][]int) {
-
-	safeChannel := make(chan bool)
-	var wg sync.WaitGroup
-
-	for _, line := range lines {
-		wg.Add(1)
-		go func(line []int, safeChannel chan bool, wg *sync.WaitGroup) {
-			defer wg.Done()
-			var waitGroup sync.WaitGroup
-			individualChannel := make(chan bool, 1)
-
-			waitGroup.Add(1)
-			go checkSafetyLevels(line, individualChannel, &waitGroup)
-			waitGroup.Wait()
-			close(individualChannel)
-			for result := range individualChannel {
-				if result {
-					safeChannel <- true
-					return
-				}
-			}
-
-			// remove each index one by one until either we hit a state where it becomes true or just send a false
-
-			for index, _ := range line {
-				waitGroup.Add(1)
-				dampeningChannel := make(chan bool, 1)
-				go checkSafetyLevels(remove(line, index), dampeningChannel, &waitGroup)
-				waitGroup.Wait()
-				close(dampeningChannel)
-				for result := range dampeningChannel {
-					if result {
-						safeChannel <- true
-						return
-					}
-				}
-			}
-
-			safeChannel <- false
-
-		}(line, safeChannel, &wg)
-	}
-
-	go func() {
-		wg.Wait()
-		close(safeChannel)
-	}()
+func part2(lines [][]int) int {
+	return countSafeReports(lines, reports.NewChecker(reports.DefaultTolerance))
+}
 
+// countSafeReports counts the reports the checker considers safe
+func countSafeReports(lines [][]int, checker *reports.Checker) int {
 	safeReports := 0
-	for safeReport := range safeChannel {
-		if safeReport {
+	for _, line := range lines {
+		if checker.Safe(line) {
 			safeReports++
 		}
 	}
-
-	fmt.Println("(part2) Safe reports found: ", safeReports)
-}
-
-// checkSafetyLevels is the function that checks each report based on the requirements of part1
-// slice must be A) ascending or descending B) differences between each level (index) cannot be less than 1 or greater than 3
-
-func checkSafetyLevels(line []int, safeChannel chan bool, wg *sync.WaitGroup) {
-	defer wg.Done()
-
-	if len(line) < 2 {
-		safeChannel <- true
-		return
-	}
-
-	trailer := line[0]
-	ascending := trailer < line[1]
-
-	for i := 1; i < len(line); i++ {
-
-		diff := trailer - line[i]
-		if diff < 0 {
-			diff = -diff
-		}
-		stillAscending := trailer < line[i]
-
-		if diff < 1 || diff > 3 || ascending != stillAscending {
-			safeChannel <- false
-			return
-		}
-		trailer = line[i]
-		ascending = stillAscending
-	}
-	safeChannel <- true
-}
-
-// remove is a simple function that makes a copy of the input slice and removes an index
-func remove(slice []int, index int) []int {
-	// Create a new slice to ensure we don't modify the original
-	result := make([]int, 0, len(slice)-1)
-	result = append(result, slice[:index]...)
-	result = append(result, slice[index+1:]...)
-	return result
+	return safeReports
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(levels(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(levels(b, input))
+	}
+}
+
+func levels(tb testing.TB, input []string) [][]int {
+	data, err := convertData(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return data
+}
+
+func FuzzConvertData(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		converted, err := convertData(lines)
+		if err == nil && len(converted) != len(lines) {
+			t.Errorf("Expected a report per line, got %d for %d lines", len(converted), len(lines))
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		if example.Part == 2 {
+			return part2(levels(t, input))
+		}
+		return part1(levels(t, input))
+	})
+}
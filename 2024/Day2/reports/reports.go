@@ -0,0 +1,148 @@
+package reports
+
+// Tolerance configures what the Checker considers a safe report
+//   - Dampeners: how many levels are allowed to be removed to make a report safe
+//   - MinDelta: smallest allowed difference between adjacent levels
+//   - MaxDelta: largest allowed difference between adjacent levels
+type Tolerance struct {
+	Dampeners int
+	MinDelta  int
+	MaxDelta  int
+}
+
+// Result holds the outcome of checking a single report
+//   - Safe: the report is safe once at most Dampeners levels are removed
+//   - FirstViolation: index of the first level that breaks the rules when nothing is removed, -1 if there is none
+//   - Removed: indices of the smallest set of levels that have to be removed to make the report safe,
+//     nil when the report can't be made safe within the dampener budget
+type Result struct {
+	Safe           bool
+	FirstViolation int
+	Removed        []int
+}
+
+// Checker checks reports against a Tolerance
+type Checker struct {
+	tolerance Tolerance
+}
+
+// DefaultTolerance is the tolerance described by the puzzle, levels must differ by at least 1 and at most 3
+// and a single bad level can be dropped
+var DefaultTolerance = Tolerance{Dampeners: 1, MinDelta: 1, MaxDelta: 3}
+
+// NewChecker creates a Checker for the given tolerance
+func NewChecker(tolerance Tolerance) *Checker {
+	return &Checker{tolerance: tolerance}
+}
+
+// Check checks a report in a single pass over its levels.
+//
+// For each direction (ascending and descending) it keeps track of the fewest removals needed for a valid run
+// that ends with level i kept. Level i can only follow one of the previous Dampeners+1 levels, since anything further
+// back would remove more levels than the budget allows, which keeps the work at O(n * Dampeners).
+func (c *Checker) Check(levels []int) Result {
+	result := Result{FirstViolation: c.firstViolation(levels)}
+	if result.FirstViolation == -1 {
+		result.Safe = true
+		result.Removed = []int{}
+		return result
+	}
+
+	var best []int
+	for _, ascending := range []bool{true, false} {
+		removed := c.minimalRemoval(levels, ascending)
+		if removed != nil && (best == nil || len(removed) < len(best)) {
+			best = removed
+		}
+	}
+
+	if best != nil {
+		result.Safe = true
+		result.Removed = best
+	}
+	return result
+}
+
+// Safe is a shorthand for Check(levels).Safe
+func (c *Checker) Safe(levels []int) bool {
+	return c.Check(levels).Safe
+}
+
+// firstViolation finds the first level that breaks the rules, where the direction is set by the first pair of levels
+// like the original part 1 check. Returns -1 if the report is safe as is
+func (c *Checker) firstViolation(levels []int) int {
+	if len(levels) < 2 {
+		return -1
+	}
+
+	ascending := levels[0] < levels[1]
+	for i := 1; i < len(levels); i++ {
+		if !c.validStep(levels[i-1], levels[i], ascending) {
+			return i
+		}
+	}
+	return -1
+}
+
+// validStep checks whether going from level "from" to level "to" is allowed in the given direction
+func (c *Checker) validStep(from, to int, ascending bool) bool {
+	diff := to - from
+	if !ascending {
+		diff = -diff
+	}
+	return diff >= c.tolerance.MinDelta && diff <= c.tolerance.MaxDelta
+}
+
+// minimalRemoval finds the smallest set of indices to remove so the report is valid in the given direction.
+// Returns nil if more than Dampeners levels would have to be removed
+func (c *Checker) minimalRemoval(levels []int, ascending bool) []int {
+	n := len(levels)
+	budget := c.tolerance.Dampeners
+	if n == 0 {
+		return []int{}
+	}
+
+	// cost[i] is the fewest removals for a valid run ending with level i kept, parent[i] the kept level before it
+	cost := make([]int, n)
+	parent := make([]int, n)
+
+	for i := 0; i < n; i++ {
+		// start a new run at i by dropping everything before it
+		cost[i] = i
+		parent[i] = -1
+
+		for p := max(0, i-budget-1); p < i; p++ {
+			if cost[p] > budget || !c.validStep(levels[p], levels[i], ascending) {
+				continue
+			}
+			if candidate := cost[p] + i - p - 1; candidate < cost[i] {
+				cost[i] = candidate
+				parent[i] = p
+			}
+		}
+	}
+
+	// the run can end at any level as long as everything after it is dropped
+	last, total := -1, budget+1
+	for i := max(0, n-budget-1); i < n; i++ {
+		if candidate := cost[i] + n - 1 - i; candidate < total {
+			last, total = i, candidate
+		}
+	}
+	if last == -1 {
+		return nil
+	}
+
+	kept := make([]bool, n)
+	for i := last; i != -1; i = parent[i] {
+		kept[i] = true
+	}
+
+	removed := make([]int, 0, total)
+	for i, keep := range kept {
+		if !keep {
+			removed = append(removed, i)
+		}
+	}
+	return removed
+}
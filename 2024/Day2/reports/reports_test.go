@@ -0,0 +1,115 @@
+package reports
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+var example = [][]int{
+	{7, 6, 4, 2, 1},
+	{1, 2, 7, 8, 9},
+	{9, 7, 6, 2, 1},
+	{1, 3, 2, 4, 5},
+	{8, 6, 4, 4, 1},
+	{1, 3, 6, 7, 9},
+}
+
+func TestChecker_Example(t *testing.T) {
+	strict := DefaultTolerance
+	strict.Dampeners = 0
+	strictChecker := NewChecker(strict)
+	dampened := NewChecker(DefaultTolerance)
+
+	wantStrict := []bool{true, false, false, false, false, true}
+	wantDampened := []bool{true, false, false, true, true, true}
+
+	for i, report := range example {
+		if got := strictChecker.Safe(report); got != wantStrict[i] {
+			t.Errorf("report %v without dampener: expected %v, got %v", report, wantStrict[i], got)
+		}
+		if got := dampened.Safe(report); got != wantDampened[i] {
+			t.Errorf("report %v with dampener: expected %v, got %v", report, wantDampened[i], got)
+		}
+	}
+}
+
+func TestChecker_FirstViolationAndRemoved(t *testing.T) {
+	checker := NewChecker(DefaultTolerance)
+
+	result := checker.Check([]int{1, 3, 2, 4, 5})
+	if result.FirstViolation != 2 {
+		t.Errorf("Expected first violation at index 2, got %d", result.FirstViolation)
+	}
+	// dropping either the 3 or the 2 makes the report safe
+	if len(result.Removed) != 1 || !slices.Contains([]int{1, 2}, result.Removed[0]) {
+		t.Errorf("Expected level 1 or 2 to be removed, got %v", result.Removed)
+	}
+
+	result = checker.Check([]int{7, 6, 4, 2, 1})
+	if result.FirstViolation != -1 || len(result.Removed) != 0 {
+		t.Errorf("Expected safe report with nothing removed, got %+v", result)
+	}
+
+	result = checker.Check([]int{1, 2, 7, 8, 9})
+	if result.Safe || result.Removed != nil {
+		t.Errorf("Expected unsafe report, got %+v", result)
+	}
+}
+
+// TestChecker_MatchesBruteForce compares the checker against removing every combination of levels
+func TestChecker_MatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for _, dampeners := range []int{0, 1, 2} {
+		checker := NewChecker(Tolerance{Dampeners: dampeners, MinDelta: 1, MaxDelta: 3})
+		for n := 0; n < 2000; n++ {
+			report := make([]int, r.Intn(8))
+			for i := range report {
+				report[i] = r.Intn(10)
+			}
+
+			result := checker.Check(report)
+			want := bruteForceRemovals(report, 1, 3)
+			if result.Safe != (want <= dampeners) {
+				t.Fatalf("report %v with %d dampeners: expected safe=%v, got %+v", report, dampeners, want <= dampeners, result)
+			}
+			if result.Safe && len(result.Removed) != want {
+				t.Fatalf("report %v: expected %d removals, got %v", report, want, result.Removed)
+			}
+		}
+	}
+}
+
+// bruteForceRemovals finds the fewest levels that need removing by trying every subset
+func bruteForceRemovals(report []int, minDelta, maxDelta int) int {
+	best := len(report)
+	for mask := 0; mask < 1<<len(report); mask++ {
+		kept := make([]int, 0)
+		for i, level := range report {
+			if mask&(1<<i) != 0 {
+				kept = append(kept, level)
+			}
+		}
+		if monotonic(kept, minDelta, maxDelta) {
+			best = min(best, len(report)-len(kept))
+		}
+	}
+	return best
+}
+
+func monotonic(levels []int, minDelta, maxDelta int) bool {
+	for _, sign := range []int{1, -1} {
+		ok := true
+		for i := 1; i < len(levels); i++ {
+			diff := (levels[i] - levels[i-1]) * sign
+			if diff < minDelta || diff > maxDelta {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
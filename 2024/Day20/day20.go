@@ -1,10 +1,13 @@
 package main
 
 import (
+	"2024/solution"
 	"2024/util"
 	"container/list"
 	"fmt"
+	"log/slog"
 	"math"
+	"os"
 )
 
 type point struct {
@@ -20,34 +23,31 @@ type cheat struct {
 const (
 	startChar = "S"
 	endChar   = "E"
+	// minSaved is how many picoseconds a cheat has to save to be counted
+	minSaved = 100
 )
 
 func main() {
-	grid := util.TransformStringSliceInto2DMatrix(util.ReadInput(util.Parameter()))
-	start, end := find(startChar, grid), find(endChar, grid)
-	part1(grid, start, end, 2)
-	part2(grid, start, end, 20)
+	grid, start, end, err := parseRacetrack(util.ReadInput(util.Parameter()))
+	if err != nil {
+		slog.Error("Unable to read the racetrack", "err", err)
+		os.Exit(1)
+	}
+	sol := solution.New(20)
+	sol.Part(1, func() any { return part1(grid, start, end, 2) })
+	sol.Part(2, func() any { return part2(grid, start, end, 20) })
 }
 
-func part1(grid [][]string, start point, end point, cheatDistance int) {
-	// need to find initial shortest path with BFS
-	// then need to calculate shortcuts
-
-	originalRoute := bfs(grid, start, end)
-	cheatRoute := findCheatPaths(originalRoute, cheatDistance)
-
-	totalSaved := 0
-	for distance, count := range cheatRoute {
-		//fmt.Println("Distance: ", distance, "Count: ", count)
-		if distance >= 100 {
-			totalSaved += count
-		}
-	}
+func part1(grid [][]string, start point, end point, cheatDistance int) int {
+	return cheatsSaving(grid, start, end, cheatDistance, minSaved)
+}
 
-	fmt.Println("(part 1) Total saved distance above 100: ", totalSaved)
+func part2(grid [][]string, start point, end point, cheatDistance int) int {
+	return cheatsSaving(grid, start, end, cheatDistance, minSaved)
 }
 
-func part2(grid [][]string, start point, end point, cheatDistance int) {
+// cheatsSaving counts the cheats of up to cheatDistance picoseconds that save at least saved picoseconds
+func cheatsSaving(grid [][]string, start point, end point, cheatDistance int, saved int) int {
 	// need to find initial shortest path with BFS
 	// then need to calculate shortcuts
 
@@ -56,13 +56,12 @@ func part2(grid [][]string, start point, end point, cheatDistance int) {
 
 	totalSaved := 0
 	for distance, count := range cheatRoute {
-		//fmt.Println("Distance: ", distance, "Count: ", count)
-		if distance >= 100 {
+		if distance >= saved {
 			totalSaved += count
 		}
 	}
 
-	fmt.Println("(part 2) Total saved distance above 100: ", totalSaved)
+	return totalSaved
 }
 
 // findCheatPaths identifies potential shortcuts in a given route and calculates the distance saved by each shortcut.
@@ -169,6 +168,32 @@ func calculateDistance(current point, neighbor point) int {
 	return int(x + y)
 }
 
+// parseRacetrack reads the racetrack and finds its start and end. The track needs exactly one of each and has to be
+// walled in, since the search doesn't check the edges of the grid
+func parseRacetrack(input []string) ([][]string, point, point, error) {
+	grid, err := util.CharGrid(input)
+	if err != nil {
+		return nil, point{}, point{}, err
+	}
+
+	counts := make(map[string]int)
+	for r, row := range grid {
+		for c, cell := range row {
+			counts[cell]++
+			edge := r == 0 || r == len(grid)-1 || c == 0 || c == len(row)-1
+			if edge && cell != "#" {
+				return nil, point{}, point{}, fmt.Errorf("the edge of the racetrack at %d,%d is %q, expected a wall", r, c, cell)
+			}
+		}
+	}
+	for _, char := range []string{startChar, endChar} {
+		if counts[char] != 1 {
+			return nil, point{}, point{}, fmt.Errorf("found %d %s tiles on the racetrack, expected 1", counts[char], char)
+		}
+	}
+	return grid, find(startChar, grid), find(endChar, grid), nil
+}
+
 // find locates the first occurrence of a specified character in a 2D grid.
 // It returns the coordinates of the character as a point struct.
 // If the character is not found, it returns a point with coordinates (-1, -1).
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/differential"
+	"2024/fuzztest"
+	"2024/gen"
+	"2024/puzzle"
+	"2024/util"
+	"math/rand/v2"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		grid, start, end := racetrack(b, input)
+		part1(grid, start, end, 2)
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		grid, start, end := racetrack(b, input)
+		part2(grid, start, end, 20)
+	}
+}
+
+func racetrack(tb testing.TB, input []string) ([][]string, point, point) {
+	grid, start, end, err := parseRacetrack(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return grid, start, end
+}
+
+// racetrackCase is a generated racetrack and how long cheats can last
+type racetrackCase struct {
+	width, height int
+	seed          uint64
+	cheat         int
+}
+
+func (c racetrackCase) grid() [][]string {
+	return util.TransformStringSliceInto2DMatrix(gen.NewRacetrack(gen.New(c.seed), c.width, c.height).Lines)
+}
+
+// distances finds the number of steps from a point to every tile of the track
+func distances(grid [][]string, from point) map[point]int {
+	steps := map[point]int{from: 0}
+	queue := []point{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range getMoves(current, 1) {
+			if _, seen := steps[next]; seen || grid[next.x][next.y] == "#" {
+				continue
+			}
+			steps[next] = steps[current] + 1
+			queue = append(queue, next)
+		}
+	}
+	return steps
+}
+
+// everyCheat tries every pair of track tiles close enough to cheat between, timing the race as the steps from the
+// start to the first tile, the cheat, and the steps from the second tile to the end. It is the reference for
+// findCheatPaths
+func everyCheat(c racetrackCase) map[int]int {
+	grid := c.grid()
+	start, end := find(startChar, grid), find(endChar, grid)
+	fromStart, toEnd := distances(grid, start), distances(grid, end)
+
+	saved := make(map[int]int)
+	for from, before := range fromStart {
+		for to, after := range toEnd {
+			cheat := calculateDistance(from, to)
+			if cheat > c.cheat {
+				continue
+			}
+			if time := before + cheat + after; time < fromStart[end] {
+				saved[fromStart[end]-time]++
+			}
+		}
+	}
+	return saved
+}
+
+func TestDifferential_FindCheatPaths(t *testing.T) {
+	differential.Run(t, differential.Test[racetrackCase]{
+		Generate: func(r *rand.Rand) racetrackCase {
+			return racetrackCase{2 + r.IntN(6), 1 + r.IntN(6), r.Uint64(), 1 + r.IntN(6)}
+		},
+		Shrink: func(c racetrackCase) []racetrackCase {
+			toReturn := make([]racetrackCase, 0)
+			for _, cheat := range differential.Smaller(c.cheat, 1) {
+				toReturn = append(toReturn, racetrackCase{c.width, c.height, c.seed, cheat})
+			}
+			for _, width := range differential.Smaller(c.width, 1) {
+				if width+c.height > 2 {
+					toReturn = append(toReturn, racetrackCase{width, c.height, c.seed, c.cheat})
+				}
+			}
+			for _, height := range differential.Smaller(c.height, 1) {
+				if c.width+height > 2 {
+					toReturn = append(toReturn, racetrackCase{c.width, height, c.seed, c.cheat})
+				}
+			}
+			return toReturn
+		},
+		Reference: func(c racetrackCase) any { return everyCheat(c) },
+		Optimized: func(c racetrackCase) any {
+			grid := c.grid()
+			return findCheatPaths(bfs(grid, find(startChar, grid), find(endChar, grid)), c.cheat)
+		},
+	})
+}
+
+func FuzzParseRacetrack(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		grid, start, end, err := parseRacetrack(lines)
+		if err != nil {
+			return
+		}
+		if len(grid) != len(lines) || grid[start.x][start.y] != startChar || grid[end.x][end.y] != endChar {
+			t.Fatalf("Expected a row per line with the start at %v and the end at %v", start, end)
+		}
+		// a cheat of 2 picoseconds is one of the cheats of up to 20
+		if short, long := part1(grid, start, end, 2), part2(grid, start, end, 20); short > long {
+			t.Errorf("Expected the %d short cheats to be among the %d long ones", short, long)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		// the example track is too short for cheats that save 100 picoseconds, the page counts the cheats that save at
+		// least 2 for part 1 and at least 50 for part 2
+		grid, start, end := racetrack(t, input)
+		if example.Part == 2 {
+			return cheatsSaving(grid, start, end, 20, 50)
+		}
+		return cheatsSaving(grid, start, end, 2, 2)
+	})
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"2024/solution"
 	"2024/util"
 	"fmt"
+	"log/slog"
+	"os"
 	"regexp"
+	"strconv"
 )
 
 type directionCombination struct {
@@ -16,35 +20,63 @@ type sequenceKey struct {
 	depth    int
 }
 
+// doorCode is a code typed on the numeric keypad and the number it starts with
+type doorCode struct {
+	sequence string
+	number   int
+}
+
 var sequenceCache = make(map[sequenceKey]int)
 
+var codePattern = regexp.MustCompile(`^([0-9]+)A$`)
+
 func main() {
-	input := util.ReadInput(util.Parameter())
-	part1(input)
-	part2(input)
+	codes, err := parseCodes(util.ReadInput(util.Parameter()))
+	if err != nil {
+		slog.Error("Unable to read the door codes", "err", err)
+		os.Exit(1)
+	}
+	sol := solution.New(21)
+	sol.Part(1, func() any { return part1(codes) })
+	sol.Part(2, func() any { return part2(codes) })
 }
 
-func part1(input []string) {
+func part1(codes []doorCode) int {
 	sum := 0
-	for _, code := range input {
-		num := parsedNum(code)
-		length := getSequenceLength(code, 3)
-		sum += length * num
+	for _, code := range codes {
+		length := getSequenceLength(code.sequence, 3)
+		sum += length * code.number
 	}
 
-	fmt.Println("(part 1) Ans: ", sum)
+	return sum
 }
 
-func part2(input []string) {
+func part2(codes []doorCode) int {
 	sum := 0
-	for _, code := range input {
-		num := parsedNum(code)
-		length := getSequenceLength(code, 26)
-		fmt.Println("Code: ", code, " Length: ", length)
-		sum += length * num
+	for _, code := range codes {
+		length := getSequenceLength(code.sequence, 26)
+		slog.Debug("Sequence length", "code", code.sequence, "length", length)
+		sum += length * code.number
 	}
 
-	fmt.Println("(part 2) Ans: ", sum)
+	return sum
+}
+
+// parseCodes reads one door code per line, digits followed by the A key
+func parseCodes(input []string) ([]doorCode, error) {
+	codes := make([]doorCode, 0, len(input))
+	for i, line := range input {
+		match := codePattern.FindStringSubmatch(line)
+		if match == nil {
+			return nil, fmt.Errorf("line %d is %q, expected digits followed by A", i+1, line)
+		}
+		number, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		codes = append(codes, doorCode{sequence: line, number: number})
+	}
+	return codes, nil
 }
 
 func getSequenceLength(targetSequence string, depth int) int {
@@ -77,13 +109,6 @@ func getMoveCount(current, next rune, depth int) int {
 	return getSequenceLength(newSequence, depth-1)
 }
 
-func parsedNum(line string) int {
-	pattern := "([0-9]+)"
-	reg := regexp.MustCompile(pattern)
-	num := reg.FindAllStringSubmatch(line, -1)
-	return util.ParseInt(num[0][0])
-}
-
 var combinations = map[directionCombination]string{
 	{'A', '0'}: "<A",
 	{'0', 'A'}: ">A",
@@ -111,7 +136,7 @@ var combinations = map[directionCombination]string{
 	{'2', '0'}: "vA",
 	{'0', '3'}: "^>A",
 	{'3', '0'}: "<vA",
-	{'0', '4'}: "^<^A",
+	{'0', '4'}: "^^<A",
 	{'4', '0'}: ">vvA",
 	{'0', '5'}: "^^A",
 	{'5', '0'}: "vvA",
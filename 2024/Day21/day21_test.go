@@ -0,0 +1,166 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/differential"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 21)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(doorCodes(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 21)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(doorCodes(b, input))
+	}
+}
+
+func doorCodes(tb testing.TB, input []string) []doorCode {
+	codes, err := parseCodes(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return codes
+}
+
+var (
+	numericKeypad     = []string{"789", "456", "123", " 0A"}
+	directionalKeypad = []string{" ^A", "<v>"}
+	arrows            = map[rune][2]int{'^': {-1, 0}, 'v': {1, 0}, '<': {0, -1}, '>': {0, 1}}
+)
+
+// keypadCase is a code to type through depth keypads, the last of them numeric
+type keypadCase struct {
+	code  string
+	depth int
+}
+
+// robotState is where every arm points and how much of the code has been typed. arms[0] is the robot the human
+// controls, the last arm is on the numeric keypad. Arrays keep the state comparable, so only the first depth arms
+// are used
+type robotState struct {
+	arms  [5][2]int
+	typed int
+}
+
+// fewestPresses finds the fewest button presses with a breadth-first search over every position of every arm,
+// the reference for getSequenceLength
+func fewestPresses(c keypadCase) int {
+	start := robotState{}
+	for i := 0; i < c.depth-1; i++ {
+		start.arms[i] = [2]int{0, 2}
+	}
+	start.arms[c.depth-1] = [2]int{3, 2}
+
+	seen := map[robotState]bool{start: true}
+	queue := []robotState{start}
+	for presses := 0; len(queue) > 0; presses++ {
+		next := make([]robotState, 0)
+		for _, state := range queue {
+			if state.typed == len(c.code) {
+				return presses
+			}
+			for _, button := range "^v<>A" {
+				moved, ok := press(state, c, 0, button)
+				if ok && !seen[moved] {
+					seen[moved] = true
+					next = append(next, moved)
+				}
+			}
+		}
+		queue = next
+	}
+	return -1
+}
+
+// press presses button on the keypad of arm, a move that points an arm at a gap or types the wrong key isn't allowed
+func press(state robotState, c keypadCase, arm int, button rune) (robotState, bool) {
+	keypad := directionalKeypad
+	if arm == c.depth-1 {
+		keypad = numericKeypad
+	}
+
+	if button != 'A' {
+		position := state.arms[arm]
+		position[0] += arrows[button][0]
+		position[1] += arrows[button][1]
+		if position[0] < 0 || position[0] >= len(keypad) || position[1] < 0 || position[1] > 2 || keypad[position[0]][position[1]] == ' ' {
+			return state, false
+		}
+		state.arms[arm] = position
+		return state, true
+	}
+
+	key := rune(keypad[state.arms[arm][0]][state.arms[arm][1]])
+	if arm < c.depth-1 {
+		return press(state, c, arm+1, key)
+	}
+	if key != rune(c.code[state.typed]) {
+		return state, false
+	}
+	state.typed++
+	return state, true
+}
+
+func TestDifferential_SequenceLength(t *testing.T) {
+	differential.Run(t, differential.Test[keypadCase]{
+		Generate: func(r *rand.Rand) keypadCase {
+			var code strings.Builder
+			for i := 0; i < 3; i++ {
+				code.WriteByte(byte('0' + r.IntN(10)))
+			}
+			code.WriteByte('A')
+			return keypadCase{code.String(), 1 + r.IntN(4)}
+		},
+		Shrink: func(c keypadCase) []keypadCase {
+			toReturn := make([]keypadCase, 0)
+			for _, depth := range differential.Smaller(c.depth, 1) {
+				toReturn = append(toReturn, keypadCase{c.code, depth})
+			}
+			for i := 0; i < len(c.code)-1; i++ {
+				toReturn = append(toReturn, keypadCase{c.code[:i] + c.code[i+1:], c.depth})
+			}
+			return toReturn
+		},
+		Reference: func(c keypadCase) any { return fewestPresses(c) },
+		Optimized: func(c keypadCase) any { return getSequenceLength(c.code, c.depth) },
+	})
+}
+
+func FuzzParseCodes(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		codes, err := parseCodes(lines)
+		if err != nil {
+			return
+		}
+		if len(codes) != len(lines) {
+			t.Fatalf("Expected a code per line, got %d for %d lines", len(codes), len(lines))
+		}
+		// every code that parses can be typed, so it takes at least a press per key
+		for _, code := range codes {
+			if length := getSequenceLength(code.sequence, 3); length < len(code.sequence) {
+				t.Errorf("Expected %q to take at least %d presses, got %d", code.sequence, len(code.sequence), length)
+			}
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		if example.Part == 2 {
+			return part2(doorCodes(t, input))
+		}
+		return part1(doorCodes(t, input))
+	})
+}
@@ -1,8 +1,13 @@
 package main
 
 import (
+	"2024/solution"
 	"2024/util"
 	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -17,12 +22,33 @@ type sequence struct {
 }
 
 func main() {
-	input := util.StringToIntSlice(util.ReadInput(util.Parameter()))
-	part1(input)
-	part2(input)
+	input, err := parseSecrets(util.ReadInput(util.Parameter()))
+	if err != nil {
+		slog.Error("Unable to read the secret numbers", "err", err)
+		os.Exit(1)
+	}
+	sol := solution.New(22)
+	sol.Part(1, func() any { return part1(input) })
+	sol.Part(2, func() any { return part2(input) })
+}
+
+// parseSecrets reads each buyer's initial secret number, one per line
+func parseSecrets(input []string) ([]int, error) {
+	secrets := make([]int, 0, len(input))
+	for i, line := range input {
+		secret, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if secret < 0 {
+			return nil, fmt.Errorf("line %d has the secret number %d, it can't be negative", i+1, secret)
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
 }
 
-func part1(input []int) {
+func part1(input []int) int {
 	secMap := make(map[int]int)
 	sum := 0
 	for _, secretNum := range input {
@@ -30,10 +56,10 @@ func part1(input []int) {
 		sum += result
 	}
 
-	fmt.Println("(part 1) Ans: ", sum)
+	return sum
 }
 
-func part2(input []int) {
+func part2(input []int) int {
 	secMap := make(map[int]int)
 	seqNumbers := make(map[sequence]int)
 
@@ -51,7 +77,7 @@ func part2(input []int) {
 		}
 	}
 
-	fmt.Println("(part 2) Ans: ", maxVal)
+	return maxVal
 }
 
 func determineSequences(secretNum int, cache *map[int]int, times int) map[sequence]int {
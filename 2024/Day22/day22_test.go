@@ -0,0 +1,54 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 22)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(secrets(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 22)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(secrets(b, input))
+	}
+}
+
+func secrets(tb testing.TB, input []string) []int {
+	numbers, err := parseSecrets(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return numbers
+}
+
+func FuzzParseSecrets(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		numbers, err := parseSecrets(lines)
+		if err != nil {
+			return
+		}
+		// each buyer sells once for a price of at most 9, so no sequence can get more than 9 from each of them
+		if bananas := part2(numbers); bananas < 0 || bananas > 9*len(numbers) {
+			t.Errorf("Expected between 0 and %d bananas, got %d", 9*len(numbers), bananas)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		if example.Part == 2 {
+			return part2(secrets(t, input))
+		}
+		return part1(secrets(t, input))
+	})
+}
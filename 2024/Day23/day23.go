@@ -1,23 +1,34 @@
 package main
 
 import (
+	"2024/solution"
 	"2024/util"
 	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
 	"slices"
 	"strings"
 )
 
+var linkPattern = regexp.MustCompile(`^(\w+)-(\w+)$`)
+
 func main() {
-	graph := makeGraph(util.ReadInput(util.Parameter()))
-	part1(graph)
-	part2(graph)
+	graph, err := makeGraph(util.ReadInput(util.Parameter()))
+	if err != nil {
+		slog.Error("Unable to read the network map", "err", err)
+		os.Exit(1)
+	}
+	sol := solution.New(23)
+	sol.Part(1, func() any { return part1(graph) })
+	sol.Part(2, func() any { return part2(graph) })
 
 }
 
 // part1 processes the graph to find all triangles (three nodes that are all connected to each other)
 // and counts how many of these triangles contain at least one node that starts with the letter 't'.
-// It prints the count of such triangles.
-func part1(graph map[string][]string) {
+// It returns the count of such triangles.
+func part1(graph map[string][]string) int {
 
 	// Create a map to store triangles, where the key is a sorted string of the three nodes
 	// and the value is a slice of the three nodes.
@@ -53,17 +64,17 @@ func part1(graph map[string][]string) {
 		}
 	}
 
-	// Print the count of triangles that contain at least one node starting with 't'.
-	fmt.Println("(part 1) Ans: ", countThatContainLetterT)
+	// Return the count of triangles that contain at least one node starting with 't'.
+	return countThatContainLetterT
 }
 
 // part2 finds the largest clique (a subset of nodes where every two nodes are connected) in the graph
-// and prints the nodes in the largest clique in sorted order.
+// and returns the nodes in the largest clique in sorted order, joined by commas.
 //
 // Parameters:
 //
 //	graph - a map where the key is a node and the value is a slice of nodes connected to the key node.
-func part2(graph map[string][]string) {
+func part2(graph map[string][]string) string {
 	var largestClique []string
 
 	// Iterate over each computer and its neighbors in the graph.
@@ -82,9 +93,9 @@ func part2(graph map[string][]string) {
 		}
 	}
 
-	// Sort the largest clique and print the result.
+	// Sort the largest clique and return the result.
 	slices.Sort(largestClique)
-	fmt.Println("(part 2) Ans: ", strings.Join(largestClique, ","))
+	return strings.Join(largestClique, ",")
 }
 
 // isFullyConnected checks if a candidate node can be added to a clique such that all nodes in the clique
@@ -108,18 +119,28 @@ func isFullyConnected(graph map[string][]string, clique []string, candidate stri
 	return true
 }
 
-func makeGraph(input []string) map[string][]string {
+// makeGraph reads the network map, one link between two named computers per line. A link that is listed twice is
+// only added once, otherwise part2 would put the same computer in a party twice
+func makeGraph(input []string) (map[string][]string, error) {
 	graph := make(map[string][]string)
 
-	for _, line := range input {
-		computers := strings.Split(line, "-")
-		computerOne := computers[0]
-		computerTwo := computers[1]
+	for i, line := range input {
+		match := linkPattern.FindStringSubmatch(line)
+		if match == nil {
+			return nil, fmt.Errorf("line %d is %q, expected two computers joined by -", i+1, line)
+		}
+		computerOne, computerTwo := match[1], match[2]
+		if computerOne == computerTwo {
+			return nil, fmt.Errorf("line %d links %s to itself", i+1, computerOne)
+		}
+		if slices.Contains(graph[computerOne], computerTwo) {
+			continue
+		}
 		graph[computerOne] = append(graph[computerOne], computerTwo)
 		graph[computerTwo] = append(graph[computerTwo], computerOne)
 
 	}
-	return graph
+	return graph, nil
 
 }
 
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"strings"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 23)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(network(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 23)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(network(b, input))
+	}
+}
+
+func network(tb testing.TB, input []string) map[string][]string {
+	graph, err := makeGraph(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return graph
+}
+
+func FuzzMakeGraph(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		graph, err := makeGraph(lines)
+		if err != nil || len(graph) == 0 {
+			return
+		}
+		// the password is a LAN party, so every computer in it has to be linked to all the others
+		party := strings.Split(part2(graph), ",")
+		for i, computer := range party {
+			if !isFullyConnected(graph, party[:i], computer) {
+				t.Errorf("Expected %s to be linked to everyone in %v", computer, party)
+			}
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		if example.Part == 2 {
+			return part2(network(t, input))
+		}
+		return part1(network(t, input))
+	})
+}
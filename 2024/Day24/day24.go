@@ -1,17 +1,18 @@
 package main
 
 import (
+	"2024/solution"
 	"2024/util"
 	"fmt"
-	"regexp"
+	"log/slog"
+	"os"
 	"slices"
 	"strconv"
 	"strings"
 )
 
 const (
-	wirePattern = `(\w+)\s*:\s*(\d+)`
-	gatePattern = `(\w+)\s+(XOR|OR|AND)\s+(\w+)\s+->\s+(\w+)`
+	gatePattern = `(?P<one>\w+)\s+(?P<operation>XOR|OR|AND)\s+(?P<two>\w+)\s+->\s+(?P<output>\w+)`
 	AND         = "AND"
 	OR          = "OR"
 	XOR         = "XOR"
@@ -24,23 +25,37 @@ type gate struct {
 	operation string
 }
 
-var wires = map[string]int{}
+// gateLine is a gate as it is written in the input, "x00 AND y00 -> z00"
+type gateLine struct {
+	InputOne  string `aoc:"one"`
+	InputTwo  string `aoc:"two"`
+	Output    string `aoc:"output"`
+	Operation string `aoc:"operation"`
+}
 
 func main() {
-	gates := parseData(util.ReadInput("Day24/day24Data.txt"))
-	part1(gates)
-	part2(gates)
+	gates, wires, err := parseData(util.ReadInput(util.Parameter()))
+	if err != nil {
+		slog.Error("Unable to read the wires and gates", "err", err)
+		os.Exit(1)
+	}
+	sol := solution.New(24)
+	sol.PartWithNotes(1, func() (any, []string) { return part1(gates, wires) })
+	sol.Part(2, func() any { return part2(gates) })
 }
 
-func part2(gates []gate) {
+func part2(gates []gate) string {
 	swaps := checkParallelAdders(gates)
 	slices.Sort(swaps)
-	fmt.Println("(part 2) Ans: ", strings.Join(swaps, ","))
-
+	return strings.Join(swaps, ",")
 }
 
+// maxSwaps is how many pairs of gate outputs the puzzle swapped
+const maxSwaps = 4
+
 // checkParallelAdders identifies and swaps parallel adders in a list of logic gates.
-// It returns a list of swapped wire names.
+// It returns a list of swapped wire names. It gives up on a circuit that isn't a ripple-carry adder, one missing the
+// gates of an input bit or needing more than maxSwaps swaps, and returns the swaps found so far.
 //
 // Parameters:
 //
@@ -54,7 +69,16 @@ func checkParallelAdders(gates []gate) []string {
 	var swaps []string
 	bit := 0
 
-	for {
+	inputBits := make(map[string]bool)
+	for _, g := range gates {
+		for _, input := range []string{g.inputOne, g.inputTwo} {
+			if strings.HasPrefix(input, "x") {
+				inputBits[input] = true
+			}
+		}
+	}
+
+	for bit < len(inputBits) {
 		// Generate wire names for the current bit position.
 		xWire := fmt.Sprintf("x%02d", bit)
 		yWire := fmt.Sprintf("y%02d", bit)
@@ -67,10 +91,16 @@ func checkParallelAdders(gates []gate) []string {
 			// For subsequent bits, find the XOR and AND gates for the current bit position.
 			abXorGate := findGate(xWire, yWire, XOR, gates)
 			abAndGate := findGate(xWire, yWire, AND, gates)
+			if abXorGate == "" || abAndGate == "" {
+				break
+			}
 
 			// Find the XOR gate between the result of the previous XOR gate and the current carry wire.
 			cinAbXorGate := findGate(abXorGate, currentCarryWire, XOR, gates)
 
+			if len(swaps) == 2*maxSwaps && cinAbXorGate != zWire {
+				break
+			}
 			if cinAbXorGate == "" {
 				// If the XOR gate is not found, swap the output wires of the XOR and AND gates,
 				// reset the bit counter, and continue the loop.
@@ -96,9 +126,6 @@ func checkParallelAdders(gates []gate) []string {
 			currentCarryWire = carryWire
 		}
 		bit++
-		if bit >= 45 {
-			break
-		}
 	}
 	return swaps
 }
@@ -126,7 +153,9 @@ func findGate(xWire string, yWire string, gate string, gates []gate) string {
 	return ""
 }
 
-func part1(gates []gate) {
+// part1 runs the gates and returns the number on the z wires, with the binary form as a note. The wires are filled in
+// as the gates run
+func part1(gates []gate, wires map[string]int) (any, []string) {
 
 	knownInputs := make([]gate, 0)
 	unknownInputs := make([]gate, 0)
@@ -139,8 +168,8 @@ func part1(gates []gate) {
 		}
 	}
 
-	processKnownGates(knownInputs)
-	processGates(unknownInputs)
+	processKnownGates(knownInputs, wires)
+	processGates(unknownInputs, wires)
 
 	zPosition := make([]string, 0)
 	for k, _ := range wires {
@@ -160,34 +189,38 @@ func part1(gates []gate) {
 	}
 
 	decimal, _ := strconv.ParseInt(binary, 2, 64)
-	fmt.Println("(part 1) Ans: ", decimal, binary)
-
+	return decimal, []string{"binary " + binary}
 }
 
-func processGates(inputs []gate) {
+// processGates keeps running the gates whose inputs are known until they all are. Gates that feed each other in a
+// loop never get known inputs, so they are left at -1 rather than retried forever
+func processGates(inputs []gate, wires map[string]int) {
 	reattempt := make([]gate, 0)
 
 	for len(inputs) > 0 {
 		for _, g := range inputs {
 			if wires[g.inputOne] != -1 && wires[g.inputTwo] != -1 {
-				processGate(g)
+				processGate(g, wires)
 			} else {
 				reattempt = append(reattempt, g)
 			}
 		}
 
+		if len(reattempt) == len(inputs) {
+			return
+		}
 		inputs = reattempt
 		reattempt = make([]gate, 0)
 	}
 }
 
-func processKnownGates(inputs []gate) {
+func processKnownGates(inputs []gate, wires map[string]int) {
 	for _, g := range inputs {
-		processGate(g)
+		processGate(g, wires)
 	}
 }
 
-func processGate(g gate) {
+func processGate(g gate, wires map[string]int) {
 	switch g.operation {
 	case "AND":
 		wires[g.output] = wires[g.inputOne] & wires[g.inputTwo]
@@ -198,28 +231,72 @@ func processGate(g gate) {
 	}
 }
 
-func parseData(input []string) []gate {
+// parseData reads the initial wire values and the gates, the two are separated by a blank line. The wires it returns
+// hold every wire, -1 for the ones a gate has yet to set.
+// Wires start at 0 or 1, each wire is driven by one initial value or one gate, and every gate input is driven
+func parseData(input []string) ([]gate, map[string]int, error) {
 	toReturn := make([]gate, 0)
+	wires := make(map[string]int)
 
-	wireReg := regexp.MustCompile(wirePattern)
-	gateReg := regexp.MustCompile(gatePattern)
+	sections, err := util.Sections(input, 2)
+	if err != nil {
+		return nil, nil, err
+	}
+	initialWires := sections[0]
 
-	splitIndex := slices.Index(input, "")
-	initialWires, gates := input[:splitIndex], input[splitIndex+1:]
+	gateDecoder, err := util.NewLineDecoder[gateLine](gatePattern)
+	if err != nil {
+		return nil, nil, err
+	}
+	gates, err := gateDecoder.DecodeAll(sections[1])
+	if err != nil {
+		return nil, nil, err
+	}
 
 	for _, wire := range initialWires {
-		wireGroups := wireReg.FindStringSubmatch(wire)
-		wireName := wireGroups[1]
-		wireVal := util.ParseInt(wireGroups[2])
+		wireName, rawVal, err := util.KeyValue(wire, ":")
+		if err != nil {
+			return nil, nil, err
+		}
+		if wireName == "" {
+			return nil, nil, fmt.Errorf("no wire name in %q", wire)
+		}
+		wireVal, err := strconv.Atoi(rawVal)
+		if err != nil {
+			return nil, nil, fmt.Errorf("wire %s: %w", wireName, err)
+		}
+		if wireVal != 0 && wireVal != 1 {
+			return nil, nil, fmt.Errorf("wire %s starts at %d, expected 0 or 1", wireName, wireVal)
+		}
+		if _, ok := wires[wireName]; ok {
+			return nil, nil, fmt.Errorf("wire %s is given more than one initial value", wireName)
+		}
 		wires[wireName] = wireVal
 	}
 
+	driven := make(map[string]bool, len(wires)+len(gates))
+	for wireName := range wires {
+		driven[wireName] = true
+	}
+	for _, rawGate := range gates {
+		if driven[rawGate.Output] {
+			return nil, nil, fmt.Errorf("wire %s is driven by more than one value or gate", rawGate.Output)
+		}
+		driven[rawGate.Output] = true
+	}
+	for _, rawGate := range gates {
+		for _, input := range []string{rawGate.InputOne, rawGate.InputTwo} {
+			if !driven[input] {
+				return nil, nil, fmt.Errorf("gate %s -> %s reads wire %s, which nothing drives", rawGate.Operation, rawGate.Output, input)
+			}
+		}
+	}
+
 	for _, rawGate := range gates {
-		gateGroups := gateReg.FindStringSubmatch(rawGate)
-		inputOne := gateGroups[1]
-		inputTwo := gateGroups[3]
-		logicGate := gateGroups[2]
-		output := gateGroups[4]
+		inputOne := rawGate.InputOne
+		inputTwo := rawGate.InputTwo
+		logicGate := rawGate.Operation
+		output := rawGate.Output
 
 		if _, ok := wires[inputOne]; !ok {
 			wires[inputOne] = -1
@@ -236,5 +313,5 @@ func parseData(input []string) []gate {
 		toReturn = append(toReturn, gate{inputOne: inputOne, inputTwo: inputTwo, output: output, operation: logicGate})
 	}
 
-	return toReturn
+	return toReturn, wires, nil
 }
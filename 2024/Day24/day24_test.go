@@ -0,0 +1,109 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/gen"
+	"2024/puzzle"
+	"2024/util"
+	"strings"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 24)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(gates(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 24)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g, _ := gates(b, input)
+		part2(g)
+	}
+}
+
+// gates parses the input, failing the benchmark if it can't
+func gates(tb testing.TB, input []string) ([]gate, map[string]int) {
+	g, wires, err := parseData(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return g, wires
+}
+
+func TestProperty_Swaps(t *testing.T) {
+	for seed := uint64(0); seed < 50; seed++ {
+		adder, err := gen.NewAdder(gen.New(seed), 45, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, _, err := parseData(adder.Lines)
+		if err != nil {
+			t.Fatalf("Seed %d: %v", seed, err)
+		}
+		if got, want := part2(parsed), strings.Join(adder.Swaps, ","); got != want {
+			t.Fatalf("Seed %d: expected the swaps %s, got %s", seed, want, got)
+		}
+	}
+}
+
+func TestProperty_Sum(t *testing.T) {
+	for seed := uint64(0); seed < 50; seed++ {
+		adder, err := gen.NewAdder(gen.New(seed), 45, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, wires, err := parseData(adder.Lines)
+		if err != nil {
+			t.Fatalf("Seed %d: %v", seed, err)
+		}
+		if got, _ := part1(parsed, wires); got != int64(adder.X+adder.Y) {
+			t.Fatalf("Seed %d: expected %d + %d = %d, got %v", seed, adder.X, adder.Y, adder.X+adder.Y, got)
+		}
+	}
+}
+
+func FuzzParseData(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		parsed, wires, err := parseData(lines)
+		if err != nil {
+			return
+		}
+		sections := util.Blocks(lines)
+		if len(parsed) != len(sections[1]) {
+			t.Fatalf("Expected a gate per line, got %d for %d lines", len(parsed), len(sections[1]))
+		}
+		// every wire is given a value or driven by a gate, and nothing else is a wire
+		if len(wires) != len(sections[0])+len(parsed) {
+			t.Fatalf("Expected %d wires, got %d", len(sections[0])+len(parsed), len(wires))
+		}
+		zWires := 0
+		for wire := range wires {
+			if strings.HasPrefix(wire, "z") {
+				zWires++
+			}
+		}
+		// the wiring can be anything, only an adder is sure to end the search for swaps, so just the gates get run.
+		// Every z wire is a bit of the number
+		_, notes := part1(parsed, wires)
+		if binary := strings.TrimPrefix(notes[0], "binary "); len(binary) != zWires || strings.Trim(binary, "01") != "" {
+			t.Errorf("Expected %d bits, got %q", zWires, binary)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		// the example isn't an adder, so only part 1 has an answer
+		if example.Part == 2 {
+			return nil
+		}
+		answer, _ := part1(gates(t, input))
+		return answer
+	})
+}
@@ -1,17 +1,30 @@
 package main
 
 import (
+	"2024/solution"
 	"2024/util"
 	"fmt"
+	"log/slog"
+	"os"
 	"strings"
 )
 
+const (
+	schematicWidth  = 5
+	schematicHeight = 7
+)
+
 func main() {
-	locks, keys := parseData(util.ReadInput(util.Parameter()))
-	part1(locks, keys)
+	locks, keys, err := parseData(util.ReadInput(util.Parameter()))
+	if err != nil {
+		slog.Error("Unable to read the schematics", "err", err)
+		os.Exit(1)
+	}
+	sol := solution.New(25)
+	sol.Part(1, func() any { return part1(locks, keys) })
 }
 
-func part1(locks [][]int, keys [][]int) {
+func part1(locks [][]int, keys [][]int) int {
 	combos := 0
 
 	for _, lock := range locks {
@@ -22,7 +35,7 @@ func part1(locks [][]int, keys [][]int) {
 		}
 	}
 
-	fmt.Println("(part 1) Ans: ", combos)
+	return combos
 }
 
 func canUnlock(lock []int, key []int) bool {
@@ -36,30 +49,34 @@ func canUnlock(lock []int, key []int) bool {
 	return true
 }
 
-func parseData(input []string) ([][]int, [][]int) {
+// parseData splits the schematics into locks and keys. Each one is 7 rows of 5 '#' or '.', a lock has its top row
+// filled and its bottom row empty, a key the other way around
+func parseData(input []string) ([][]int, [][]int, error) {
 	locks := make([][]int, 0)
 	keys := make([][]int, 0)
 
-	rawInputs := make([][]string, 0)
-	rawInput := make([]string, 0)
-	for _, line := range input {
-		if line == "" {
-			rawInputs = append(rawInputs, rawInput)
-			rawInput = make([]string, 0)
-			continue
+	for i, rawInput := range util.Blocks(input) {
+		if len(rawInput) != schematicHeight {
+			return nil, nil, fmt.Errorf("schematic %d has %d rows, expected %d", i+1, len(rawInput), schematicHeight)
+		}
+		for _, row := range rawInput {
+			if len(row) != schematicWidth || strings.Trim(row, "#.") != "" {
+				return nil, nil, fmt.Errorf("schematic %d has the row %q, expected %d of '#' or '.'", i+1, row, schematicWidth)
+			}
 		}
-		rawInput = append(rawInput, line)
-	}
-	rawInputs = append(rawInputs, rawInput)
 
-	for _, rawInput := range rawInputs {
-		if strings.Join(strings.Fields(rawInput[0]), "") == "#####" {
+		filled, empty := strings.Repeat("#", schematicWidth), strings.Repeat(".", schematicWidth)
+		top, bottom := rawInput[0], rawInput[schematicHeight-1]
+		switch {
+		case top == filled && bottom == empty:
 			locks = append(locks, parseLockKey(rawInput, true))
-		} else {
+		case top == empty && bottom == filled:
 			keys = append(keys, parseLockKey(rawInput, false))
+		default:
+			return nil, nil, fmt.Errorf("schematic %d is neither a lock nor a key", i+1)
 		}
 	}
-	return locks, keys
+	return locks, keys, nil
 }
 
 func parseLockKey(input []string, islock bool) []int {
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 25)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		locks, keys, err := parseData(input)
+		if err != nil {
+			b.Fatal(err)
+		}
+		part1(locks, keys)
+	}
+}
+
+func FuzzParseData(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		locks, keys, err := parseData(lines)
+		if err != nil {
+			return
+		}
+		for _, pins := range append(locks, keys...) {
+			if len(pins) != schematicWidth {
+				t.Fatalf("Expected %d pin heights, got %v", schematicWidth, pins)
+			}
+		}
+		if pairs := part1(locks, keys); pairs > len(locks)*len(keys) {
+			t.Errorf("Expected at most %d pairs that fit, got %d", len(locks)*len(keys), pairs)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		locks, keys, err := parseData(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if example.Part == 2 {
+			return nil
+		}
+		return part1(locks, keys)
+	})
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"2024/Day3/interpreter"
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	filename := bench.InputPath(b, 3)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(filename)
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	filename := bench.InputPath(b, 3)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(filename)
+	}
+}
+
+func FuzzRun(f *testing.F) {
+	fuzztest.Corpus(f)
+	f.Fuzz(func(t *testing.T, data string) {
+		machine, err := interpreter.New(true).Run(strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("Corrupted memory is never an error, got %v", err)
+		}
+		// the lexer skips line breaks wherever they are, so the op and its bracket can be split across lines
+		unbroken := strings.NewReplacer("\n", "", "\r", "")
+		for _, instruction := range machine.Executed {
+			if instruction.Offset < 0 || !strings.HasPrefix(unbroken.Replace(data[instruction.Offset:]), instruction.Op+"(") {
+				t.Errorf("%s was executed from offset %d, which doesn't hold it", instruction.Op, instruction.Offset)
+			}
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		// the interpreter reads the program straight from the file
+		filename := filepath.Join("testdata", example.Input)
+		if example.Part == 2 {
+			return part2(filename)
+		}
+		return part1(filename)
+	})
+}
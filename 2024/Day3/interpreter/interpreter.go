@@ -0,0 +1,166 @@
+package interpreter
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Op describes an instruction the interpreter understands, written as name(arg,arg,...)
+//   - Name: name of the instruction, matched against the end of a word so xmul( still finds mul(
+//   - Arity: number of integer arguments
+//   - MaxDigits: maximum number of digits per argument, 0 means there is no limit
+//   - Control: control instructions (like do and don't) always run and are skipped entirely when conditionals aren't honored
+//   - Exec: what the instruction does to the machine
+type Op struct {
+	Name      string
+	Arity     int
+	MaxDigits int
+	Control   bool
+	Exec      func(m *Machine, args []int)
+}
+
+// Instruction is an instruction that was executed
+//   - Op: name of the instruction
+//   - Args: the arguments it was called with
+//   - Offset: byte offset of the instruction in the input
+type Instruction struct {
+	Op     string
+	Args   []int
+	Offset int64
+}
+
+// Machine is the state the instructions run against
+//   - Enabled: whether non control instructions currently run
+//   - Sum: running total the instructions add to
+//   - Executed: every instruction that ran, in order
+type Machine struct {
+	Enabled  bool
+	Sum      int
+	Executed []Instruction
+}
+
+// Interpreter runs the instruction language hidden in corrupted memory
+type Interpreter struct {
+	ops               map[string]Op
+	honorConditionals bool
+}
+
+// Mul multiplies its two arguments and adds them to the machine's sum
+var Mul = Op{Name: "mul", Arity: 2, MaxDigits: 3, Exec: func(m *Machine, args []int) {
+	m.Sum += args[0] * args[1]
+}}
+
+// Do enables future instructions
+var Do = Op{Name: "do", Control: true, Exec: func(m *Machine, args []int) {
+	m.Enabled = true
+}}
+
+// Dont disables future instructions
+var Dont = Op{Name: "don't", Control: true, Exec: func(m *Machine, args []int) {
+	m.Enabled = false
+}}
+
+// New creates an Interpreter that knows mul, do and don't. When honorConditionals is false do() and don't() are ignored
+func New(honorConditionals bool) *Interpreter {
+	in := &Interpreter{ops: make(map[string]Op), honorConditionals: honorConditionals}
+	in.Register(Mul)
+	in.Register(Do)
+	in.Register(Dont)
+	return in
+}
+
+// Register adds an instruction to the language, replacing any instruction with the same name
+func (in *Interpreter) Register(op Op) {
+	in.ops[op.Name] = op
+}
+
+// Run reads the program from r and executes every well-formed instruction it finds
+func (in *Interpreter) Run(r io.Reader) (*Machine, error) {
+	machine := &Machine{Enabled: true, Executed: make([]Instruction, 0)}
+	lexer := NewLexer(r)
+
+	token, err := lexer.Next()
+	for err == nil && token.Kind != EOF {
+		op, offset, ok := in.lookup(token)
+		if !ok {
+			token, err = lexer.Next()
+			continue
+		}
+
+		// a failed parse hands back the token it stopped on, since it could be the start of the next instruction
+		var args []int
+		args, token, err = in.parseArgs(lexer, op)
+		if err != nil || args == nil {
+			continue
+		}
+
+		in.execute(machine, op, Instruction{Op: op.Name, Args: args, Offset: offset})
+		token, err = lexer.Next()
+	}
+
+	return machine, err
+}
+
+// execute runs a parsed instruction if the machine state allows it
+func (in *Interpreter) execute(machine *Machine, op Op, instruction Instruction) {
+	if op.Control && !in.honorConditionals {
+		return
+	}
+	if !op.Control && !machine.Enabled {
+		return
+	}
+	op.Exec(machine, instruction.Args)
+	machine.Executed = append(machine.Executed, instruction)
+}
+
+// lookup finds the longest registered instruction name the word ends with, along with the offset the name starts at
+func (in *Interpreter) lookup(token Token) (Op, int64, bool) {
+	if token.Kind != Word {
+		return Op{}, 0, false
+	}
+
+	var found Op
+	ok := false
+	for name, op := range in.ops {
+		if strings.HasSuffix(token.Text, name) && len(name) > len(found.Name) {
+			found, ok = op, true
+		}
+	}
+	return found, token.offsetAt(len(token.Text) - len(found.Name)), ok
+}
+
+// parseArgs parses (arg,arg,...) after an instruction name. When the arguments are malformed it returns nil along with the
+// token that broke the pattern, otherwise it returns the arguments and the token after the closing paren
+func (in *Interpreter) parseArgs(lexer *Lexer, op Op) ([]int, Token, error) {
+	token, err := lexer.Next()
+	if err != nil || token.Kind != LeftParen {
+		return nil, token, err
+	}
+
+	args := make([]int, 0, op.Arity)
+	for i := 0; i < op.Arity; i++ {
+		if i > 0 {
+			if token, err = lexer.Next(); err != nil || token.Kind != Comma {
+				return nil, token, err
+			}
+		}
+
+		if token, err = lexer.Next(); err != nil || token.Kind != Number {
+			return nil, token, err
+		}
+		if op.MaxDigits > 0 && len(token.Text) > op.MaxDigits {
+			return nil, token, nil
+		}
+		value, convErr := strconv.Atoi(token.Text)
+		if convErr != nil {
+			return nil, token, nil
+		}
+		args = append(args, value)
+	}
+
+	if token, err = lexer.Next(); err != nil || token.Kind != RightParen {
+		return nil, token, err
+	}
+	return args, token, nil
+}
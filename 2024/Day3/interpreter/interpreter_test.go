@@ -0,0 +1,73 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	examplePart1 = "xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))"
+	examplePart2 = "xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))"
+)
+
+func TestInterpreter_Examples(t *testing.T) {
+	machine, err := New(false).Run(strings.NewReader(examplePart1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if machine.Sum != 161 {
+		t.Errorf("Expected sum 161, got %d", machine.Sum)
+	}
+
+	machine, err = New(true).Run(strings.NewReader(examplePart2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if machine.Sum != 48 {
+		t.Errorf("Expected sum 48, got %d", machine.Sum)
+	}
+
+	machine, err = New(false).Run(strings.NewReader(examplePart2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if machine.Sum != 161 {
+		t.Errorf("Expected do() and don't() to be ignored, got sum %d", machine.Sum)
+	}
+}
+
+func TestInterpreter_Offsets(t *testing.T) {
+	machine, err := New(true).Run(strings.NewReader("xmul(2,4)\nmul(1234,5)x\nmul(3,3)don't()mul(1,1)"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Instruction{
+		{Op: "mul", Args: []int{2, 4}, Offset: 1},
+		{Op: "mul", Args: []int{3, 3}, Offset: 23},
+		{Op: "don't", Offset: 31},
+	}
+	if len(machine.Executed) != len(expected) {
+		t.Fatalf("Expected %d executed instructions, got %+v", len(expected), machine.Executed)
+	}
+	for i, instruction := range machine.Executed {
+		if instruction.Op != expected[i].Op || instruction.Offset != expected[i].Offset {
+			t.Errorf("Expected %+v, got %+v", expected[i], instruction)
+		}
+	}
+}
+
+func TestInterpreter_Register(t *testing.T) {
+	in := New(true)
+	in.Register(Op{Name: "add", Arity: 2, Exec: func(m *Machine, args []int) {
+		m.Sum += args[0] + args[1]
+	}})
+
+	machine, err := in.Run(strings.NewReader("add(1000,1)mul(2,3)don't()add(5,5)"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if machine.Sum != 1007 {
+		t.Errorf("Expected sum 1007, got %d", machine.Sum)
+	}
+}
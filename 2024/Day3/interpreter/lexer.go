@@ -0,0 +1,134 @@
+package interpreter
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// TokenKind identifies what kind of token the lexer produced
+type TokenKind int
+
+const (
+	Word       TokenKind = iota // run of letters and apostrophes, e.g. mul or don't
+	Number                      // run of digits
+	LeftParen                   // (
+	RightParen                  // )
+	Comma                       // ,
+	Junk                        // any other byte
+	EOF                         // end of input
+)
+
+// Token is a single lexeme read from the corrupted memory
+//   - Kind: what kind of token this is
+//   - Text: the raw text of the token
+//   - Offset: byte offset of the first character of the token in the input
+type Token struct {
+	Kind   TokenKind
+	Text   string
+	Offset int64
+	// offsets holds the input offset of every byte of a run, line breaks inside the run make them skip ahead
+	offsets []int64
+}
+
+// offsetAt returns the byte offset in the input of byte i of the token's text
+func (t Token) offsetAt(i int) int64 {
+	if i < len(t.offsets) {
+		return t.offsets[i]
+	}
+	return t.Offset + int64(i)
+}
+
+// Lexer splits corrupted memory into tokens while reading it from an io.Reader.
+// Line breaks are skipped, so the input behaves as if every line was joined together
+type Lexer struct {
+	reader *bufio.Reader
+	offset int64
+}
+
+// NewLexer creates a Lexer that reads from r
+func NewLexer(r io.Reader) *Lexer {
+	return &Lexer{reader: bufio.NewReader(r)}
+}
+
+// Next reads the next token, returning a token of kind EOF once the input is exhausted
+func (l *Lexer) Next() (Token, error) {
+	b, offset, err := l.readByte()
+	if errors.Is(err, io.EOF) {
+		return Token{Kind: EOF, Offset: offset}, nil
+	}
+	if err != nil {
+		return Token{}, err
+	}
+
+	switch {
+	case isWordByte(b):
+		return l.readRun(b, offset, Word, isWordByte)
+	case isDigit(b):
+		return l.readRun(b, offset, Number, isDigit)
+	case b == '(':
+		return Token{Kind: LeftParen, Text: "(", Offset: offset}, nil
+	case b == ')':
+		return Token{Kind: RightParen, Text: ")", Offset: offset}, nil
+	case b == ',':
+		return Token{Kind: Comma, Text: ",", Offset: offset}, nil
+	default:
+		return Token{Kind: Junk, Text: string(b), Offset: offset}, nil
+	}
+}
+
+// readRun keeps reading bytes as long as they belong to the same class as the first one
+func (l *Lexer) readRun(first byte, offset int64, kind TokenKind, belongs func(byte) bool) (Token, error) {
+	text := []byte{first}
+	offsets := []int64{offset}
+	for {
+		b, err := l.peekByte()
+		if errors.Is(err, io.EOF) || (err == nil && !belongs(b)) {
+			return Token{Kind: kind, Text: string(text), Offset: offset, offsets: offsets}, nil
+		}
+		if err != nil {
+			return Token{}, err
+		}
+		next, nextOffset, _ := l.readByte()
+		text = append(text, next)
+		offsets = append(offsets, nextOffset)
+	}
+}
+
+// readByte reads the next byte that isn't a line break and returns its offset
+func (l *Lexer) readByte() (byte, int64, error) {
+	for {
+		b, err := l.reader.ReadByte()
+		if err != nil {
+			return 0, l.offset, err
+		}
+		offset := l.offset
+		l.offset++
+		if b != '\n' && b != '\r' {
+			return b, offset, nil
+		}
+	}
+}
+
+// peekByte looks at the next byte that isn't a line break without consuming it
+func (l *Lexer) peekByte() (byte, error) {
+	for {
+		peeked, err := l.reader.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		if peeked[0] != '\n' && peeked[0] != '\r' {
+			return peeked[0], nil
+		}
+		l.reader.ReadByte()
+		l.offset++
+	}
+}
+
+func isWordByte(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || b == '\'' || b == '_'
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
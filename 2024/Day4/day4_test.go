@@ -0,0 +1,65 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"2024/util"
+	"strings"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(util.TransformStringSliceInto2DMatrix(input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(util.TransformStringSliceInto2DMatrix(input))
+	}
+}
+
+func FuzzCharGrid(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		grid, err := util.CharGrid(lines)
+		if err != nil {
+			return
+		}
+		// the grid has a row per line, holding the line's characters
+		if len(grid) != len(lines) {
+			t.Fatalf("Expected a row per line, got %d rows for %d lines", len(grid), len(lines))
+		}
+		for r, row := range grid {
+			if strings.Join(row, "") != lines[r] || len(row) != len(grid[0]) {
+				t.Fatalf("Expected row %d to be %q as %d cells, got %q", r, lines[r], len(grid[0]), row)
+			}
+		}
+		// a word can start in 8 directions from every cell, a cross is centered on a cell
+		cells := len(grid) * len(grid[0])
+		if words := part1(grid); words > 8*cells {
+			t.Errorf("Expected at most %d words in %d cells, got %d", 8*cells, cells, words)
+		}
+		if crosses := part2(grid); crosses > cells {
+			t.Errorf("Expected at most %d crosses in %d cells, got %d", cells, cells, crosses)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		grid, err := util.CharGrid(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if example.Part == 2 {
+			return part2(grid)
+		}
+		return part1(grid)
+	})
+}
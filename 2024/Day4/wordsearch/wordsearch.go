@@ -0,0 +1,223 @@
+package wordsearch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Directions holds the 8 directions a word can be written in
+var Directions = [][2]int{
+	{0, -1},  // Left
+	{0, 1},   // Right
+	{-1, 0},  // Up
+	{1, 0},   // Down
+	{-1, -1}, // Up-Left
+	{-1, 1},  // Up-Right
+	{1, -1},  // Down-Left
+	{1, 1},   // Down-Right
+}
+
+// Match is a single occurrence of a word in the grid
+//   - Word: the word that was found
+//   - Start: row and column of the first letter
+//   - Direction: row and column step between letters
+//   - Path: row and column of every letter of the word
+type Match struct {
+	Word      string
+	Start     [2]int
+	Direction [2]int
+	Path      [][2]int
+}
+
+// Find searches the grid for every occurrence of every word in any of the 8 directions.
+// A palindrome is reported once for each direction it can be read in
+func Find(grid [][]string, words ...string) []Match {
+	matches := make([]Match, 0)
+	if len(grid) == 0 {
+		return matches
+	}
+
+	for _, word := range words {
+		letters := strings.Split(word, "")
+		if len(letters) == 0 {
+			continue
+		}
+		for r := 0; r < len(grid); r++ {
+			for c := 0; c < len(grid[r]); c++ {
+				if grid[r][c] != letters[0] {
+					continue
+				}
+				for _, dir := range Directions {
+					if path, ok := follow(grid, letters, r, c, dir); ok {
+						matches = append(matches, Match{Word: word, Start: [2]int{r, c}, Direction: dir, Path: path})
+					}
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// follow walks the word from (row, col) in the given direction, returns the path if every letter matched
+func follow(grid [][]string, letters []string, row, col int, dir [2]int) ([][2]int, bool) {
+	path := make([][2]int, 0, len(letters))
+	for k, letter := range letters {
+		r, c := row+k*dir[0], col+k*dir[1]
+		if !inBounds(grid, r, c) || grid[r][c] != letter {
+			return nil, false
+		}
+		path = append(path, [2]int{r, c})
+	}
+	return path, true
+}
+
+// Stencil is a small shape of letters to search for, like the X-MAS cross.
+// Cells holding the wildcard in the template match anything
+type Stencil struct {
+	rows, cols int
+	cells      []stencilCell
+}
+
+// stencilCell is a letter of the stencil with its offset from the top left corner of the template
+type stencilCell struct {
+	offset [2]int
+	letter string
+}
+
+// NewStencil creates a stencil out of a template grid, where every row is a string and wildcard marks cells that match anything
+//
+//	M.S
+//	.A.   -> the X-MAS cross with "." as the wildcard
+//	M.S
+func NewStencil(template []string, wildcard string) (Stencil, error) {
+	stencil := Stencil{rows: len(template)}
+	for r, line := range template {
+		letters := strings.Split(line, "")
+		if r > 0 && len(letters) != stencil.cols {
+			return Stencil{}, fmt.Errorf("stencil row %d has %d cells, expected %d", r, len(letters), stencil.cols)
+		}
+		stencil.cols = len(letters)
+		for c, letter := range letters {
+			if letter != wildcard {
+				stencil.cells = append(stencil.cells, stencilCell{[2]int{r, c}, letter})
+			}
+		}
+	}
+
+	if len(stencil.cells) == 0 {
+		return Stencil{}, fmt.Errorf("stencil has no letters to match")
+	}
+	return stencil, nil
+}
+
+// Rotate returns the stencil rotated 90 degrees clockwise
+func (s Stencil) Rotate() Stencil {
+	rotated := Stencil{rows: s.cols, cols: s.rows, cells: make([]stencilCell, len(s.cells))}
+	for i, cell := range s.cells {
+		rotated.cells[i] = stencilCell{[2]int{cell.offset[1], s.rows - 1 - cell.offset[0]}, cell.letter}
+	}
+	return rotated
+}
+
+// Rotations returns every distinct rotation of the stencil, starting with the stencil itself
+func (s Stencil) Rotations() []Stencil {
+	rotations := []Stencil{s}
+	current := s
+	for i := 0; i < 3; i++ {
+		current = current.Rotate()
+		duplicate := false
+		for _, seen := range rotations {
+			if seen.equals(current) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			rotations = append(rotations, current)
+		}
+	}
+	return rotations
+}
+
+// equals checks if two stencils match exactly the same cells
+func (s Stencil) equals(other Stencil) bool {
+	if s.rows != other.rows || s.cols != other.cols || len(s.cells) != len(other.cells) {
+		return false
+	}
+	letters := make(map[[2]int]string)
+	for _, cell := range s.cells {
+		letters[cell.offset] = cell.letter
+	}
+	for _, cell := range other.cells {
+		if letter, ok := letters[cell.offset]; !ok || letter != cell.letter {
+			return false
+		}
+	}
+	return true
+}
+
+// StencilMatch is a single placement of a stencil in the grid
+//   - Stencil: index of the stencil that matched, in the order they were passed to FindStencils
+//   - Origin: row and column of the top left corner of the stencil's template
+//   - Path: row and column of every non wildcard cell
+type StencilMatch struct {
+	Stencil int
+	Origin  [2]int
+	Path    [][2]int
+}
+
+// FindStencils searches the grid for every placement of every stencil
+func FindStencils(grid [][]string, stencils ...Stencil) []StencilMatch {
+	matches := make([]StencilMatch, 0)
+	for index, stencil := range stencils {
+		for r := 0; r+stencil.rows <= len(grid); r++ {
+			for c := 0; c+stencil.cols <= len(grid[r]); c++ {
+				if path, ok := stencil.matchAt(grid, r, c); ok {
+					matches = append(matches, StencilMatch{Stencil: index, Origin: [2]int{r, c}, Path: path})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// matchAt checks the stencil with its top left corner at (row, col)
+func (s Stencil) matchAt(grid [][]string, row, col int) ([][2]int, bool) {
+	path := make([][2]int, 0, len(s.cells))
+	for _, cell := range s.cells {
+		r, c := row+cell.offset[0], col+cell.offset[1]
+		if !inBounds(grid, r, c) || grid[r][c] != cell.letter {
+			return nil, false
+		}
+		path = append(path, [2]int{r, c})
+	}
+	return path, true
+}
+
+// Highlight renders the grid with only the cells on the given paths shown, every other cell is replaced with "."
+func Highlight(grid [][]string, paths ...[][2]int) string {
+	keep := make(map[[2]int]bool)
+	for _, path := range paths {
+		for _, cell := range path {
+			keep[cell] = true
+		}
+	}
+
+	var builder strings.Builder
+	for r, row := range grid {
+		for c, letter := range row {
+			if keep[[2]int{r, c}] {
+				builder.WriteString(letter)
+			} else {
+				builder.WriteString(".")
+			}
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// inBounds is a helper bounds checking function
+func inBounds(grid [][]string, r, c int) bool {
+	return r >= 0 && r < len(grid) && c >= 0 && c < len(grid[r])
+}
@@ -0,0 +1,83 @@
+package wordsearch
+
+import (
+	"strings"
+	"testing"
+)
+
+var example = []string{
+	"MMMSXXMASM",
+	"MSAMXMSMSA",
+	"AMXSXMAAMM",
+	"MSAMASMSMX",
+	"XMASAMXAMM",
+	"XXAMMXXAMA",
+	"SMSMSASXSS",
+	"SAXAMASAAA",
+	"MAMMMXMMMM",
+	"MXMXAXMASX",
+}
+
+func exampleGrid() [][]string {
+	grid := make([][]string, 0)
+	for _, line := range example {
+		grid = append(grid, strings.Split(line, ""))
+	}
+	return grid
+}
+
+func TestFind_Example(t *testing.T) {
+	matches := Find(exampleGrid(), "XMAS")
+	if len(matches) != 18 {
+		t.Errorf("Expected 18 matches, got %d", len(matches))
+	}
+
+	for _, match := range matches {
+		if len(match.Path) != 4 || match.Path[0] != match.Start {
+			t.Errorf("Unexpected path for match %+v", match)
+		}
+	}
+}
+
+func TestFindStencils_Example(t *testing.T) {
+	stencil, err := NewStencil([]string{"M.S", ".A.", "M.S"}, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotations := stencil.Rotations()
+	if len(rotations) != 4 {
+		t.Fatalf("Expected 4 distinct rotations, got %d", len(rotations))
+	}
+
+	matches := FindStencils(exampleGrid(), rotations...)
+	if len(matches) != 9 {
+		t.Errorf("Expected 9 matches, got %d", len(matches))
+	}
+}
+
+func TestNewStencil_Invalid(t *testing.T) {
+	if _, err := NewStencil([]string{"M.S", ".A"}, "."); err == nil {
+		t.Error("Expected error for ragged template")
+	}
+	if _, err := NewStencil([]string{"..", ".."}, "."); err == nil {
+		t.Error("Expected error for template with only wildcards")
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	grid := [][]string{
+		{"X", "M", "A", "S"},
+		{"A", "B", "C", "D"},
+	}
+	expected := "XMAS\n....\n"
+	matches := Find(grid, "XMAS")
+
+	paths := make([][][2]int, 0)
+	for _, match := range matches {
+		paths = append(paths, match.Path)
+	}
+	if got := Highlight(grid, paths...); got != expected {
+		t.Errorf("Unexpected highlight:\n%s", got)
+	}
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"2024/parallel"
+	"2024/solution"
 	"2024/util"
 	"container/list"
+	"context"
 	"fmt"
-	"strings"
-	"sync"
+	"log/slog"
+	"os"
 )
 
 /*
@@ -17,102 +20,84 @@ import (
 
 func main() {
 	rawInput := util.ReadInput(util.Parameter())
-	order, updates := separateData(rawInput)
-	graph := makeGraph(order)
-	convertedUpdates := convertUpdates(updates)
-	part1(graph, convertedUpdates)
-	part2(graph, convertedUpdates)
+	order, updates, err := separateData(rawInput)
+	if err != nil {
+		slog.Error("Unable to read the print queue", "err", err)
+		os.Exit(1)
+	}
+	graph, err := makeGraph(order)
+	if err != nil {
+		slog.Error("Unable to read the page ordering rules", "err", err)
+		os.Exit(1)
+	}
+	convertedUpdates, err := convertUpdates(updates)
+	if err != nil {
+		slog.Error("Unable to read the updates", "err", err)
+		os.Exit(1)
+	}
+	sol := solution.New(5)
+	sol.Part(1, func() any { return part1(graph, convertedUpdates) })
+	sol.Part(2, func() any { return part2(graph, convertedUpdates) })
 }
 
-// separateData separates the order (page ranks) from the updates
-func separateData(rawInput []string) (order, updates []string) {
-
-	index := 0
-	for index < len(rawInput) {
-		if strings.Contains(rawInput[index], "|") {
-			order = append(order, rawInput[index])
-		} else {
-			break
-		}
-		index++
+// separateData separates the order (page ranks) from the updates, a blank line is between them
+func separateData(rawInput []string) (order, updates []string, err error) {
+	sections, err := util.Sections(rawInput, 2)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	updates = append(updates, rawInput[index+1:]...)
-	return
+	return sections[0], sections[1], nil
 }
 
 // part1 Take an update and perform the topological sort, if its a valid update (matches sort results)
 // then keep the update
-func part1(graph map[int][]int, updates [][]int) {
-	var wg sync.WaitGroup
-	results := make(chan []int, len(updates))
-
-	for _, update := range updates {
-		wg.Add(1)
-		go func(update []int) {
-			defer wg.Done()
-			topSort := topologicalSort(update, graph)
-			if validateUpdate(update, topSort) {
-				results <- update
-			}
-		}(update)
-	}
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	sum := 0
-	for result := range results {
-		sum += result[len(result)/2]
-	}
-
-	fmt.Println("(part 1) Ans: ", sum)
+func part1(graph map[int][]int, updates [][]int) int {
+	sum, _ := parallel.MapReduce(context.Background(), updates, func(_ context.Context, update []int) (int, error) {
+		topSort := topologicalSort(update, graph)
+		if validateUpdate(update, topSort) {
+			return update[len(update)/2], nil
+		}
+		return 0, nil
+	}, 0, parallel.Sum[int])
 
+	return sum
 }
 
 // part2 does a very similar process to part1, expect in situations where the update is not valid, then keep the sort results
-func part2(graph map[int][]int, updates [][]int) {
-
-	var wg sync.WaitGroup
-	results := make(chan []int, len(updates))
-
-	for _, update := range updates {
-		wg.Add(1)
-		go func(update []int) {
-			defer wg.Done()
-			topSort := topologicalSort(update, graph)
-			if !validateUpdate(update, topSort) {
-				results <- topSort
-			}
-		}(update)
-	}
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	sum := 0
-	for result := range results {
-		sum += result[len(result)/2]
-	}
-
-	fmt.Println("(part 2) Ans: ", sum)
+func part2(graph map[int][]int, updates [][]int) int {
+	sum, _ := parallel.MapReduce(context.Background(), updates, func(_ context.Context, update []int) (int, error) {
+		topSort := topologicalSort(update, graph)
+		if len(topSort) != len(update) {
+			slog.Warn("The rules for an update go in circles, it can't be put in order", "update", update)
+			return 0, nil
+		}
+		if !validateUpdate(update, topSort) {
+			return topSort[len(topSort)/2], nil
+		}
+		return 0, nil
+	}, 0, parallel.Sum[int])
 
+	return sum
 }
 
 // makeGraph creates the overall graph out of the provided order
-func makeGraph(order []string) map[int][]int {
+func makeGraph(order []string) (map[int][]int, error) {
 	graph := make(map[int][]int)
 	for _, vertex := range order {
-		parts := strings.Split(vertex, "|")
-		from := util.ParseInt(parts[0])
-		to := util.ParseInt(parts[1])
+		pages, err := util.Ints(vertex)
+		if err != nil {
+			return nil, err
+		}
+		if len(pages) != 2 {
+			return nil, fmt.Errorf("rule %q should be two pages", vertex)
+		}
+		from, to := pages[0], pages[1]
+		if from == to {
+			return nil, fmt.Errorf("rule %q puts a page before itself", vertex)
+		}
 		graph[from] = append(graph[from], to)
 	}
-	return graph
+	return graph, nil
 }
 
 // topologicalSort performs a topological sort on by creating a subgraph of the provided update
@@ -192,16 +177,18 @@ func validateUpdate(update []int, topOrder []int) bool {
 	return true
 }
 
-// convertUpdates converts the string input into a usable format
-func convertUpdates(updateStr []string) [][]int {
+// convertUpdates converts the string input into a usable format, every update needs at least one page
+func convertUpdates(updateStr []string) ([][]int, error) {
 	toReturn := make([][]int, 0)
-	for _, update := range updateStr {
-		converted := make([]int, 0)
-		split := strings.Split(update, ",")
-		for _, value := range split {
-			converted = append(converted, util.ParseInt(value))
+	for i, update := range updateStr {
+		converted, err := util.Ints(update)
+		if err != nil {
+			return nil, err
+		}
+		if len(converted) == 0 {
+			return nil, fmt.Errorf("update %d has no pages", i+1)
 		}
 		toReturn = append(toReturn, converted)
 	}
-	return toReturn
+	return toReturn, nil
 }
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/gen"
+	"2024/puzzle"
+	"slices"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(printQueue(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(printQueue(b, input))
+	}
+}
+
+func printQueue(tb testing.TB, input []string) (map[int][]int, [][]int) {
+	order, updates, err := separateData(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	graph, err := makeGraph(order)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	converted, err := convertUpdates(updates)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return graph, converted
+}
+
+func TestProperty_HiddenOrder(t *testing.T) {
+	for seed := uint64(0); seed < 100; seed++ {
+		queue := gen.NewPrintQueue(gen.New(seed), 5+int(seed%20), 30)
+		if got := part1(printQueue(t, queue.Lines)); got != queue.Ordered {
+			t.Fatalf("Seed %d: expected part 1 to be %d, got %d", seed, queue.Ordered, got)
+		}
+		if got := part2(printQueue(t, queue.Lines)); got != queue.Reordered {
+			t.Fatalf("Seed %d: expected part 2 to be %d, got %d", seed, queue.Reordered, got)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		order, updates, err := separateData(lines)
+		if err != nil {
+			return
+		}
+		graph, err := makeGraph(order)
+		if err != nil {
+			return
+		}
+		converted, err := convertUpdates(updates)
+		if err != nil {
+			return
+		}
+		rules := 0
+		for _, after := range graph {
+			rules += len(after)
+		}
+		if rules != len(order) || len(converted) != len(updates) {
+			t.Fatalf("Expected %d rules and %d updates, got %d and %d", len(order), len(updates), rules, len(converted))
+		}
+		// whatever the rules say, even when they go in circles, the sort only puts an update's pages in order, each
+		// one once, and keeps every rule between the pages it places
+		for _, update := range converted {
+			sorted := topologicalSort(update, graph)
+			position := make(map[int]int, len(sorted))
+			for i, page := range sorted {
+				if _, ok := position[page]; ok || !slices.Contains(update, page) {
+					t.Fatalf("Expected the pages of %v at most once, got %v", update, sorted)
+				}
+				position[page] = i
+			}
+			for before, after := range graph {
+				for _, page := range after {
+					i, ok := position[before]
+					j, ok2 := position[page]
+					if ok && ok2 && i > j {
+						t.Fatalf("Expected %d before %d in %v", before, page, sorted)
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		if example.Part == 2 {
+			return part2(printQueue(t, input))
+		}
+		return part1(printQueue(t, input))
+	})
+}
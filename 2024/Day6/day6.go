@@ -1,175 +1,112 @@
 package main
 
 import (
+	"2024/Day6/patrol"
+	"2024/recorder"
+	"2024/solution"
 	"2024/util"
 	"fmt"
+	"log/slog"
 	"os"
 )
 
 const (
-	up       = "^"
-	down     = "V"
-	left     = "<"
-	right    = ">"
-	obstacle = "#"
-	free     = "."
+	visited     = "X"
+	cycle       = "+"
+	newObstacle = "O"
 )
 
-type state struct {
-	X, Y             int
-	currentDirection string
-}
-
 /*
 	Day 6 Advent of Code
 	Part 1: Just needed to follow the path of the guard and capture all his unique positions
 	Part 2: I got stuck on this for a long time, I was trying to find some novel solution where on the guards path I could calculate the best spot to put an obstacle then detect the loop.
-			I gave up and took the brute force path and simulated the guards path for every possible obstacle placement, and this ended up working
+			I originally took the brute force path and simulated the guards path for every possible obstacle placement. The patrol package now only tries
+			obstacles on the guard's original path, and jumps from obstacle to obstacle with precomputed jump tables instead of walking cell by cell
 */
 
 func main() {
-	data := util.TransformStringSliceInto2DMatrix(util.ReadInput(util.Parameter()))
-
-	changeDirections := map[string]string{
-		up:    right,
-		right: down,
-		down:  left,
-		left:  up,
+	data, err := util.CharGrid(util.ReadInput(util.Parameter()))
+	if err != nil {
+		slog.Error("Unable to read the lab", "err", err)
+		os.Exit(1)
 	}
 
-	moveDirections := map[string][2]int{
-		up:    {-1, 0},
-		down:  {1, 0},
-		left:  {0, -1},
-		right: {0, 1},
+	lab, err := patrol.NewLab(data)
+	if err != nil {
+		slog.Error("Something is wrong with the grid", "err", err)
+		os.Exit(1)
 	}
 
-	part1(data, changeDirections, moveDirections)
-	part2(data, changeDirections, moveDirections)
+	rec := recorder.FromFlags()
+	sol := solution.New(6)
+	sol.Part(1, func() any { return part1(lab, data, rec) })
+	sol.Part(2, func() any { return part2(lab, data, rec) })
+	if err := rec.Close(); err != nil {
+		slog.Error("Unable to save recording", "err", err)
+	}
 }
 
 // part1 finds all the unique positions of the guard's path
-func part1(grid [][]string, changeDirections map[string]string, moveDirections map[string][2]int) {
-
-	seenSpaces := util.NewHashSet()
-
-	currentX, currentY, currentDirection := findInitialPosition(grid)
-	if currentX == -1 {
-		fmt.Println("Something is wrong with the grid, no guard was found")
-		os.Exit(1)
-	}
-
-	seenSpaces.Add([2]int{currentX, currentY})
-	stillInGrid := true
-	for stillInGrid {
-
-		// check space in front, if there is a obstacle then turn
-		if obstacleInFront(currentX, currentY, grid, currentDirection, moveDirections) {
-			currentDirection = changeDirections[currentDirection]
-		} else {
-			// move once space
-			dx, dy := moveDirections[currentDirection][0], moveDirections[currentDirection][1]
-			currentX, currentY = currentX+dx, currentY+dy
-
-			stillInGrid = inBounds(currentX, currentY, grid)
-
-			if !seenSpaces.Contains([2]int{currentX, currentY}) && stillInGrid {
-				seenSpaces.Add([2]int{currentX, currentY})
-			}
+func part1(lab *patrol.Lab, grid [][]string, rec *recorder.Recorder) int {
+	path := lab.Path()
+
+	if rec.Enabled() {
+		frame := copyGrid(grid)
+		for step, cell := range path {
+			frame[cell[0]][cell[1]] = visited
+			rec.Capture(frame, fmt.Sprintf("(part 1) step %d", step))
 		}
-
 	}
 
-	fmt.Println("(part 1) Ans: ", seenSpaces.Size())
-
+	return len(path)
 }
 
 // part2 calculates every possible obstacle position to force loops in the guard's path
-func part2(grid [][]string, changeDirections map[string]string, moveDirections map[string][2]int) {
-	validObstacles := 0
+func part2(lab *patrol.Lab, grid [][]string, rec *recorder.Recorder) int {
+	loops := lab.FindLoops()
 
-	R, C := len(grid), len(grid[0]) // Grid dimensions
-
-	// Iterate over all possible positions in the grid
-	for obsR := 0; obsR < R; obsR++ {
-		for obsC := 0; obsC < C; obsC++ {
-			// Skip if the cell already has an obstacle
-			if grid[obsR][obsC] == "#" {
-				continue
-			}
-
-			// Simulate guard's movement with the obstacle at (obsR, obsC)
-			if causedLoop(grid, obsR, obsC, moveDirections, changeDirections) {
-				validObstacles++
-			}
+	if rec.Enabled() {
+		for _, loop := range loops {
+			rec.Capture(drawLoop(grid, loop), fmt.Sprintf("(part 2) obstacle at %d,%d", loop.Obstacle[0], loop.Obstacle[1]))
 		}
 	}
 
-	fmt.Printf("(part 2) Ans: %d\n", validObstacles)
+	return len(loops)
 }
 
-// causedLoop is the helper function for part2, where it simulates the guards path until we possibly encounter obsR & obsC
-// if we do then we force the guard to turn and simulate hitting a obstacle
-func causedLoop(grid [][]string, obsR, obsC int, moveDirections map[string][2]int, changeDirections map[string]string) bool {
-	R, C := len(grid), len(grid[0])
-	startingRow, startingCol, currentDirection := findInitialPosition(grid) // Guard's starting position
-	SEEN := make(map[state]bool)                                            // Track visited (r, c, direction)
-
-	r, c := startingRow, startingCol
-	for {
-		currentState := state{r, c, currentDirection}
-		if SEEN[currentState] {
-			return true // Loop detected
-		}
-		SEEN[currentState] = true
-
-		// Calculate next position
-		dr, dc := moveDirections[currentDirection][0], moveDirections[currentDirection][1]
-		nr, nc := r+dr, c+dc
-
-		// Check for grid exit
-		if nr < 0 || nr >= R || nc < 0 || nc >= C {
-			return false // Guard exited the grid, no loop
-		}
-
-		// Simulate obstacle at (obsR, obsC)
-		if grid[nr][nc] == "#" || (nr == obsR && nc == obsC) {
-			// Turn right
-			currentDirection = changeDirections[currentDirection]
-		} else {
-			// Move forward
-			r, c = nr, nc
-		}
-	}
-}
-
-// obstacleInFront helper function for part 1, checks if the square in front of guard has be obstacle
-func obstacleInFront(x int, y int, grid [][]string, direction string, moveDirections map[string][2]int) bool {
-	dx, dy := moveDirections[direction][0]+x, moveDirections[direction][1]+y
-	if inBounds(dx, dy, grid) {
-		if grid[dx][dy] == obstacle {
-			return true
+// drawLoop draws the cycle the guard gets stuck in along with the obstacle that caused it.
+// The guard walks in a straight line from one state of the cycle to the next
+func drawLoop(grid [][]string, loop patrol.Loop) [][]string {
+	frame := copyGrid(grid)
+	for i, from := range loop.Cycle {
+		to := loop.Cycle[(i+1)%len(loop.Cycle)]
+		dr, dc := sign(to.Row-from.Row), sign(to.Col-from.Col)
+		for r, c := from.Row, from.Col; ; r, c = r+dr, c+dc {
+			frame[r][c] = cycle
+			if r == to.Row && c == to.Col {
+				break
+			}
 		}
 	}
-	return false
+	frame[loop.Obstacle[0]][loop.Obstacle[1]] = newObstacle
+	return frame
 }
 
-// findInitialPosition locates the guard's initial position
-func findInitialPosition(grid [][]string) (int, int, string) {
-	for r := 0; r < len(grid); r++ {
-		for c := 0; c < len(grid[r]); c++ {
-			if grid[r][c] == up || grid[r][c] == down || grid[r][c] == left || grid[r][c] == right {
-				return r, c, grid[r][c]
-			}
-		}
+// copyGrid makes a copy of the grid so frames can be drawn on it
+func copyGrid(grid [][]string) [][]string {
+	toReturn := make([][]string, len(grid))
+	for r := range grid {
+		toReturn[r] = append([]string{}, grid[r]...)
 	}
-	return -1, -1, ""
+	return toReturn
 }
 
-// inBounds is a helper bounds checking function
-func inBounds(x, y int, grid [][]string) bool {
-	if x < 0 || x >= len(grid) || y < 0 || y >= len(grid[0]) {
-		return false
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
 	}
-	return true
+	return 0
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"2024/Day6/patrol"
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"2024/util"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 6)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		grid := util.TransformStringSliceInto2DMatrix(input)
+		lab, err := patrol.NewLab(grid)
+		if err != nil {
+			b.Fatal(err)
+		}
+		part1(lab, grid, nil)
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 6)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		grid := util.TransformStringSliceInto2DMatrix(input)
+		lab, err := patrol.NewLab(grid)
+		if err != nil {
+			b.Fatal(err)
+		}
+		part2(lab, grid, nil)
+	}
+}
+
+func FuzzNewLab(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		grid, err := util.CharGrid(lines)
+		if err != nil {
+			return
+		}
+		lab, err := patrol.NewLab(grid)
+		if err != nil {
+			return
+		}
+		// the guard only walks through open cells, and counts each of them once
+		path := lab.Path()
+		seen := make(map[[2]int]bool, len(path))
+		for _, cell := range path {
+			if seen[cell] || grid[cell[0]][cell[1]] == "#" {
+				t.Fatalf("Expected every cell of the path once and open, got %v", path)
+			}
+			seen[cell] = true
+		}
+		if part1(lab, grid, nil) != len(path) {
+			t.Errorf("Expected part 1 to count the %d cells of the path", len(path))
+		}
+		// an obstacle can't go where there is one already or where the guard stands, and a cell can only take one
+		start := [2]int{lab.Start().Row, lab.Start().Col}
+		obstacles := make(map[[2]int]bool)
+		for _, loop := range lab.FindLoops() {
+			if obstacles[loop.Obstacle] || loop.Obstacle == start || grid[loop.Obstacle[0]][loop.Obstacle[1]] == "#" {
+				t.Fatalf("Expected obstacle %v on a free cell, once", loop.Obstacle)
+			}
+			obstacles[loop.Obstacle] = true
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		grid, err := util.CharGrid(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lab, err := patrol.NewLab(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if example.Part == 2 {
+			return part2(lab, grid, nil)
+		}
+		return part1(lab, grid, nil)
+	})
+}
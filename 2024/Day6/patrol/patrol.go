@@ -0,0 +1,263 @@
+package patrol
+
+import (
+	"2024/parallel"
+	"context"
+	"fmt"
+)
+
+// Direction the guard is facing, turning right is always (direction + 1) % 4
+type Direction int
+
+const (
+	Up Direction = iota
+	Right
+	Down
+	Left
+)
+
+// moves holds the row and column step for every Direction
+var moves = [4][2]int{
+	Up:    {-1, 0},
+	Right: {0, 1},
+	Down:  {1, 0},
+	Left:  {0, -1},
+}
+
+// guardSymbols maps the characters the guard can be drawn as to the direction they are facing
+var guardSymbols = map[string]Direction{
+	"^": Up,
+	">": Right,
+	"V": Down,
+	"v": Down,
+	"<": Left,
+}
+
+const obstacle = "#"
+
+// State is the guard's position and the direction the guard is facing
+type State struct {
+	Row, Col  int
+	Direction Direction
+}
+
+// Loop is an obstacle placement that traps the guard
+//   - Obstacle: row and column of the new obstacle
+//   - Cycle: the states the guard keeps repeating, one per turn, in the order they are reached
+type Loop struct {
+	Obstacle [2]int
+	Cycle    []State
+}
+
+// Lab is the map the guard patrols, along with jump tables that hold where the guard stops when walking
+// from any cell in any direction
+type Lab struct {
+	rows, cols int
+	blocked    []bool
+	start      State
+	// jump[d][cell] is the last free cell before the next obstacle when walking from cell in direction d, or -1 if the guard walks off the map
+	jump [4][]int
+}
+
+// NewLab creates a Lab out of the puzzle grid and builds the jump tables
+func NewLab(grid [][]string) (*Lab, error) {
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return nil, fmt.Errorf("lab map is empty")
+	}
+
+	lab := &Lab{rows: len(grid), cols: len(grid[0]), start: State{-1, -1, Up}}
+	lab.blocked = make([]bool, lab.rows*lab.cols)
+	for r, row := range grid {
+		if len(row) != lab.cols {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", r, len(row), lab.cols)
+		}
+		for c, cell := range row {
+			if cell == obstacle {
+				lab.blocked[lab.index(r, c)] = true
+			} else if direction, ok := guardSymbols[cell]; ok {
+				lab.start = State{r, c, direction}
+			}
+		}
+	}
+	if lab.start.Row == -1 {
+		return nil, fmt.Errorf("no guard was found on the map")
+	}
+
+	lab.buildJumpTables()
+	return lab, nil
+}
+
+// Start returns the guard's starting state
+func (lab *Lab) Start() State {
+	return lab.start
+}
+
+// buildJumpTables sweeps every row and column once per direction, carrying the last free cell seen before an obstacle
+func (lab *Lab) buildJumpTables() {
+	for d := range lab.jump {
+		lab.jump[d] = make([]int, len(lab.blocked))
+	}
+
+	for r := 0; r < lab.rows; r++ {
+		// walking left stops at the cell right of the closest obstacle on the left
+		stop := -1
+		for c := 0; c < lab.cols; c++ {
+			if lab.blocked[lab.index(r, c)] {
+				stop = lab.index(r, c+1)
+				continue
+			}
+			lab.jump[Left][lab.index(r, c)] = stop
+		}
+		stop = -1
+		for c := lab.cols - 1; c >= 0; c-- {
+			if lab.blocked[lab.index(r, c)] {
+				stop = lab.index(r, c-1)
+				continue
+			}
+			lab.jump[Right][lab.index(r, c)] = stop
+		}
+	}
+
+	for c := 0; c < lab.cols; c++ {
+		stop := -1
+		for r := 0; r < lab.rows; r++ {
+			if lab.blocked[lab.index(r, c)] {
+				stop = lab.index(r+1, c)
+				continue
+			}
+			lab.jump[Up][lab.index(r, c)] = stop
+		}
+		stop = -1
+		for r := lab.rows - 1; r >= 0; r-- {
+			if lab.blocked[lab.index(r, c)] {
+				stop = lab.index(r-1, c)
+				continue
+			}
+			lab.jump[Down][lab.index(r, c)] = stop
+		}
+	}
+}
+
+// Path walks the guard cell by cell until the guard leaves the map (or starts repeating the patrol) and returns every
+// distinct cell visited, in the order they are first reached
+func (lab *Lab) Path() [][2]int {
+	seen := make([]bool, len(lab.blocked))
+	states := make(map[State]bool)
+	path := make([][2]int, 0)
+
+	state := lab.start
+	for !states[state] {
+		states[state] = true
+		cell := lab.index(state.Row, state.Col)
+		if !seen[cell] {
+			seen[cell] = true
+			path = append(path, [2]int{state.Row, state.Col})
+		}
+
+		nr, nc := state.Row+moves[state.Direction][0], state.Col+moves[state.Direction][1]
+		if !lab.inBounds(nr, nc) {
+			return path
+		}
+		if lab.blocked[lab.index(nr, nc)] {
+			state.Direction = (state.Direction + 1) % 4
+		} else {
+			state.Row, state.Col = nr, nc
+		}
+	}
+	return path
+}
+
+// FindLoops tries an obstacle on every cell of the guard's original path (besides the starting cell), since an obstacle
+// anywhere else is never reached. Candidates are checked in parallel and the loops come back in path order
+func (lab *Lab) FindLoops() []Loop {
+	candidates := lab.Path()[1:]
+	results, _ := parallel.Map(context.Background(), candidates, func(_ context.Context, obstacle [2]int) (*Loop, error) {
+		if cycle := lab.loopWith(obstacle); cycle != nil {
+			return &Loop{Obstacle: obstacle, Cycle: cycle}, nil
+		}
+		return nil, nil
+	})
+
+	loops := make([]Loop, 0)
+	for _, loop := range results {
+		if loop != nil {
+			loops = append(loops, *loop)
+		}
+	}
+	return loops
+}
+
+// loopWith simulates the patrol with an extra obstacle, jumping from turn to turn with the jump tables.
+// Returns the repeating cycle of states if the guard gets stuck, nil if the guard leaves the map
+func (lab *Lab) loopWith(extra [2]int) []State {
+	seen := make(map[State]int)
+	order := make([]State, 0)
+
+	state := lab.start
+	for {
+		if first, ok := seen[state]; ok {
+			return order[first:]
+		}
+		seen[state] = len(order)
+		order = append(order, state)
+
+		next, ok := lab.walk(state, extra)
+		if !ok {
+			return nil
+		}
+		state = next
+	}
+}
+
+// walk moves the guard from state until the guard hits an obstacle (including the extra one) and turns right.
+// Returns false if the guard walks off the map instead
+func (lab *Lab) walk(state State, extra [2]int) (State, bool) {
+	stop := lab.jump[state.Direction][lab.index(state.Row, state.Col)]
+
+	// the extra obstacle cuts the walk short if it sits between the guard and where the guard would have stopped
+	if lab.between(state, stop, extra) {
+		state.Row, state.Col = extra[0]-moves[state.Direction][0], extra[1]-moves[state.Direction][1]
+		state.Direction = (state.Direction + 1) % 4
+		return state, true
+	}
+
+	if stop == -1 {
+		return state, false
+	}
+	state.Row, state.Col = stop/lab.cols, stop%lab.cols
+	state.Direction = (state.Direction + 1) % 4
+	return state, true
+}
+
+// between checks if cell lies ahead of the guard, no further than the stop cell (or the edge of the map if stop is -1)
+func (lab *Lab) between(state State, stop int, cell [2]int) bool {
+	move := moves[state.Direction]
+	dr, dc := cell[0]-state.Row, cell[1]-state.Col
+
+	var steps int
+	switch {
+	case move[0] == 0 && dr == 0:
+		steps = dc * move[1]
+	case move[1] == 0 && dc == 0:
+		steps = dr * move[0]
+	default:
+		return false
+	}
+	if steps <= 0 {
+		return false
+	}
+	if stop == -1 {
+		return true
+	}
+
+	stopSteps := (stop/lab.cols-state.Row)*move[0] + (stop%lab.cols-state.Col)*move[1]
+	return steps <= stopSteps
+}
+
+func (lab *Lab) index(r, c int) int {
+	return r*lab.cols + c
+}
+
+func (lab *Lab) inBounds(r, c int) bool {
+	return r >= 0 && r < lab.rows && c >= 0 && c < lab.cols
+}
@@ -0,0 +1,62 @@
+package patrol
+
+import (
+	"strings"
+	"testing"
+)
+
+var example = []string{
+	"....#.....",
+	".........#",
+	"..........",
+	"..#.......",
+	".......#..",
+	"..........",
+	".#..^.....",
+	"........#.",
+	"#.........",
+	"......#...",
+}
+
+func exampleLab(t *testing.T) *Lab {
+	grid := make([][]string, 0)
+	for _, line := range example {
+		grid = append(grid, strings.Split(line, ""))
+	}
+	lab, err := NewLab(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lab
+}
+
+func TestLab_Path(t *testing.T) {
+	lab := exampleLab(t)
+	if got := len(lab.Path()); got != 41 {
+		t.Errorf("Expected 41 visited cells, got %d", got)
+	}
+}
+
+func TestLab_FindLoops(t *testing.T) {
+	lab := exampleLab(t)
+	loops := lab.FindLoops()
+	if len(loops) != 6 {
+		t.Fatalf("Expected 6 loops, got %d", len(loops))
+	}
+
+	expected := map[[2]int]bool{{6, 3}: true, {7, 6}: true, {7, 7}: true, {8, 1}: true, {8, 3}: true, {9, 7}: true}
+	for _, loop := range loops {
+		if !expected[loop.Obstacle] {
+			t.Errorf("Unexpected obstacle %v", loop.Obstacle)
+		}
+		if len(loop.Cycle) == 0 {
+			t.Errorf("Expected a cycle for obstacle %v", loop.Obstacle)
+		}
+	}
+}
+
+func TestNewLab_NoGuard(t *testing.T) {
+	if _, err := NewLab([][]string{{".", "#"}}); err == nil {
+		t.Error("Expected error when there is no guard")
+	}
+}
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"2024/parallel"
+	"2024/solution"
 	"2024/util"
+	"context"
 	"fmt"
+	"log/slog"
+	"os"
 	"strconv"
 	"strings"
-	"sync"
 )
 
 // equations is struct where:
@@ -25,137 +29,119 @@ type equations struct {
 	Part 2: Took code from part 1  (It would be a lot of work to refactor to compartmentalize reusable code, and there is enough of a distinction from the part 1 code that I felt it was fine). Added a third operation to dp, which was concatenation
 			Which is to put the two number together like 5 || 6 => 56. Just converted both elements to a string, concatenated them, and parsed the int out.
 
-	Since I chose to use dynamic programming, I was able to keep it non-recursive so felt much better about making this solution concurrent, where each possible equation is calculated on its own goroutine
+	Since I chose to use dynamic programming, I was able to keep it non-recursive so felt much better about making this solution concurrent, where the equations are spread over the parallel package's worker pool
 */
 
 func main() {
 	inpput := util.ReadInput(util.Parameter())
-	equationList := parseEquations(inpput)
-	part1(equationList)
-	part2(equationList)
+	equationList, err := parseEquations(inpput)
+	if err != nil {
+		slog.Error("Unable to read the equations", "err", err)
+		os.Exit(1)
+	}
+	sol := solution.New(7)
+	sol.Part(1, func() any { return part1(equationList) })
+	sol.Part(2, func() any { return part2(equationList) })
 }
 
 // part2 is the dynamic programming solution, uses the same flow as part1 but adds the third operator
-func part2(eqs []equations) {
-	var wg sync.WaitGroup
-	results := make(chan int, len(eqs))
-
-	for _, equation := range eqs {
-		wg.Add(1)
-		go func(equation equations, results chan int, wg *sync.WaitGroup) {
-			defer wg.Done()
-			target := equation.target
+func part2(eqs []equations) int {
+	sum, _ := parallel.MapReduce(context.Background(), eqs, func(_ context.Context, equation equations) (int, error) {
+		target := equation.target
 
-			dp := make([]util.HashSet, len(equation.values))
-
-			for index := 0; index < len(equation.values); index++ {
-				dp[index] = *util.NewHashSet()
-			}
+		dp := make([]util.HashSet, len(equation.values))
 
-			dp[0].Add(equation.values[0])
-
-			for i := 1; i < len(equation.values); i++ {
-				currentTable := dp[i-1].ToSlice()
-				for _, val := range currentTable {
-					dp[i].Add(val.(int) + equation.values[i])
-					dp[i].Add(val.(int) * equation.values[i])
-					// To concatenation
-					leftPart := strconv.Itoa(val.(int))
-					rightPart := strconv.Itoa(equation.values[i])
-					dp[i].Add(util.ParseInt(leftPart + rightPart))
-				}
-			}
+		for index := 0; index < len(equation.values); index++ {
+			dp[index] = *util.NewHashSet()
+		}
 
-			if dp[len(equation.values)-1].Contains(target) {
-				results <- target
+		dp[0].Add(equation.values[0])
+
+		for i := 1; i < len(equation.values); i++ {
+			currentTable := dp[i-1].ToSlice()
+			for _, val := range currentTable {
+				dp[i].Add(val.(int) + equation.values[i])
+				dp[i].Add(val.(int) * equation.values[i])
+				// To concatenation
+				leftPart := strconv.Itoa(val.(int))
+				rightPart := strconv.Itoa(equation.values[i])
+				dp[i].Add(util.ParseInt(leftPart + rightPart))
 			}
-		}(equation, results, &wg)
-
-	}
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
+		}
 
-	sum := 0
-	for result := range results {
-		sum += result
-	}
+		if dp[len(equation.values)-1].Contains(target) {
+			return target, nil
+		}
+		return 0, nil
+	}, 0, parallel.Sum[int])
 
-	fmt.Println("(part 2) Ans: ", sum)
+	return sum
 }
 
 // part1 solves the prompt using dynamic programming, read above for thought process
-func part1(eqs []equations) {
-
-	var wg sync.WaitGroup
-	results := make(chan int, len(eqs))
+func part1(eqs []equations) int {
 
-	for _, equation := range eqs {
-		wg.Add(1)
-		go func(equation equations, results chan int, wg *sync.WaitGroup) {
-			defer wg.Done()
-			target := equation.target
+	sum, _ := parallel.MapReduce(context.Background(), eqs, func(_ context.Context, equation equations) (int, error) {
+		target := equation.target
 
-			// create dp table
-			dp := make([]util.HashSet, len(equation.values))
+		// create dp table
+		dp := make([]util.HashSet, len(equation.values))
 
-			// go requires you to explicitly initialize every index
-			for index := 0; index < len(equation.values); index++ {
-				dp[index] = *util.NewHashSet()
-			}
+		// go requires you to explicitly initialize every index
+		for index := 0; index < len(equation.values); index++ {
+			dp[index] = *util.NewHashSet()
+		}
 
-			// populate the first number into the equation
-			dp[0].Add(equation.values[0])
-
-			// for each number after 0, we are going to find the product and sum with every other number
-			// that exists in the previous table entry
-			for i := 1; i < len(equation.values); i++ {
-				currentTable := dp[i-1].ToSlice()
-				for _, val := range currentTable {
-					dp[i].Add(val.(int) + equation.values[i])
-					dp[i].Add(val.(int) * equation.values[i])
-				}
-			}
+		// populate the first number into the equation
+		dp[0].Add(equation.values[0])
 
-			// check last dp table entry to see if target is present, if it is, then send target on channel
-			if dp[len(equation.values)-1].Contains(target) {
-				results <- target
+		// for each number after 0, we are going to find the product and sum with every other number
+		// that exists in the previous table entry
+		for i := 1; i < len(equation.values); i++ {
+			currentTable := dp[i-1].ToSlice()
+			for _, val := range currentTable {
+				dp[i].Add(val.(int) + equation.values[i])
+				dp[i].Add(val.(int) * equation.values[i])
 			}
-		}(equation, results, &wg)
-
-	}
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	sum := 0
-	for result := range results {
-		sum += result
-	}
+		}
 
-	fmt.Println("(part 1) Ans: ", sum)
+		// check last dp table entry to see if target is present, if it is, then it counts towards the sum
+		if dp[len(equation.values)-1].Contains(target) {
+			return target, nil
+		}
+		return 0, nil
+	}, 0, parallel.Sum[int])
 
+	return sum
 }
 
-// parseEquations extracts the target val and list of numbers that potentially equate to target
-func parseEquations(input []string) []equations {
+// parseEquations extracts the target val and list of numbers that potentially equate to target.
+// Every line is "target: value value ...", with at least one value and no negative numbers
+func parseEquations(input []string) ([]equations, error) {
 
 	toReturn := make([]equations, 0)
-	for _, line := range input {
-		split := strings.Split(line, " ")
-		targetStr := split[0]
-		targetStr = targetStr[:len(targetStr)-1]
+	for i, line := range input {
+		targetStr, valuesStr, err := util.KeyValue(line, ":")
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
 
-		target := util.ParseInt(targetStr)
+		target, err := strconv.Atoi(targetStr)
+		if err != nil || target < 0 {
+			return nil, fmt.Errorf("line %d: the target %q is not a number", i+1, targetStr)
+		}
 		values := make([]int, 0)
-		for i := 1; i < len(split); i++ {
-			values = append(values, util.ParseInt(split[i]))
+		for _, valueStr := range strings.Fields(valuesStr) {
+			value, err := strconv.Atoi(valueStr)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("line %d: the value %q is not a number", i+1, valueStr)
+			}
+			values = append(values, value)
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("line %d has no values", i+1)
 		}
 		toReturn = append(toReturn, equations{target: target, values: values})
 	}
-	return toReturn
+	return toReturn, nil
 }
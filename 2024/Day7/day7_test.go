@@ -0,0 +1,58 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(equationList(b, input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(equationList(b, input))
+	}
+}
+
+func equationList(tb testing.TB, input []string) []equations {
+	eqs, err := parseEquations(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return eqs
+}
+
+func FuzzParseEquations(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		eqs, err := parseEquations(lines)
+		if err != nil {
+			return
+		}
+		if len(eqs) != len(lines) {
+			t.Fatalf("Expected an equation per line, got %d for %d lines", len(eqs), len(lines))
+		}
+		for _, eq := range eqs {
+			if len(eq.values) == 0 {
+				t.Errorf("Expected every equation to have values, got %+v", eq)
+			}
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		if example.Part == 2 {
+			return part2(equationList(t, input))
+		}
+		return part1(equationList(t, input))
+	})
+}
@@ -0,0 +1,187 @@
+package antinode
+
+import (
+	"fmt"
+	"slices"
+)
+
+const empty = "."
+
+// Mode selects which points along the line through a pair of antennas count as antinodes
+type Mode int
+
+const (
+	// Exact only counts the two points where one antenna is exactly twice as far away as the other
+	Exact Mode = iota
+	// Harmonics counts every lattice point on the line through the pair, including the antennas themselves
+	Harmonics
+	// Ratio counts the two points outside the pair where one antenna is Ratio[0]/Ratio[1] times as far away as the other
+	Ratio
+)
+
+// Position holds the x (column) and y (row) coordinates of an antenna or antinode
+type Position struct {
+	X, Y int
+}
+
+// Antinode is an antinode along with the pair of antennas that produced it
+type Antinode struct {
+	Position Position
+	Pair     [2]Position
+}
+
+// Options configure how antinodes are found
+//   - Mode: which points along the line count as antinodes
+//   - Ratio: distance ratio used by the Ratio mode, as numerator and denominator
+//   - Frequencies: only antennas with these frequencies are used, every frequency is used when empty
+//   - Unbounded: keep antinodes that fall outside the map, not allowed with Harmonics since there are infinitely many
+type Options struct {
+	Mode        Mode
+	Ratio       [2]int
+	Frequencies []string
+	Unbounded   bool
+}
+
+// Antennas finds all antenna positions (non empty spots) in the grid, grouped by frequency
+func Antennas(grid [][]string) map[string][]Position {
+	positions := make(map[string][]Position)
+
+	for y, row := range grid {
+		for x, cell := range row {
+			if cell != empty {
+				positions[cell] = append(positions[cell], Position{x, y})
+			}
+		}
+	}
+	return positions
+}
+
+// Find finds the antinodes of every pair of antennas with the same frequency, grouped by frequency.
+// The same position is listed once for every pair that produces it
+func Find(grid [][]string, opts Options) (map[string][]Antinode, error) {
+	ratio, err := opts.ratio()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Mode == Harmonics && opts.Unbounded {
+		return nil, fmt.Errorf("harmonics can't be unbounded, the line through a pair never ends")
+	}
+
+	rows := len(grid)
+	cols := 0
+	if rows > 0 {
+		cols = len(grid[0])
+	}
+	inBounds := func(p Position) bool {
+		return opts.Unbounded || (p.X >= 0 && p.X < cols && p.Y >= 0 && p.Y < rows)
+	}
+
+	antinodes := make(map[string][]Antinode)
+	for frequency, antennas := range Antennas(grid) {
+		if len(opts.Frequencies) > 0 && !slices.Contains(opts.Frequencies, frequency) {
+			continue
+		}
+
+		found := make([]Antinode, 0)
+		for i := 0; i < len(antennas); i++ {
+			for j := i + 1; j < len(antennas); j++ {
+				pair := [2]Position{antennas[i], antennas[j]}
+
+				var points []Position
+				if opts.Mode == Harmonics {
+					points = harmonics(pair[0], pair[1], inBounds)
+				} else {
+					points = ratioPoints(pair[0], pair[1], ratio)
+				}
+
+				for _, point := range points {
+					if inBounds(point) {
+						found = append(found, Antinode{Position: point, Pair: pair})
+					}
+				}
+			}
+		}
+		antinodes[frequency] = found
+	}
+	return antinodes, nil
+}
+
+// Unique collects the distinct antinode positions across every frequency
+func Unique(antinodes map[string][]Antinode) map[Position]bool {
+	unique := make(map[Position]bool)
+	for _, found := range antinodes {
+		for _, antinode := range found {
+			unique[antinode.Position] = true
+		}
+	}
+	return unique
+}
+
+// ratio returns the distance ratio for the selected mode
+func (opts Options) ratio() ([2]int, error) {
+	switch opts.Mode {
+	case Exact:
+		return [2]int{2, 1}, nil
+	case Harmonics:
+		return [2]int{}, nil
+	case Ratio:
+		num, den := opts.Ratio[0], opts.Ratio[1]
+		if num <= 0 || den <= 0 || num == den {
+			return [2]int{}, fmt.Errorf("invalid ratio %d:%d, both sides must be positive and different", num, den)
+		}
+		if num < den {
+			num, den = den, num
+		}
+		return [2]int{num, den}, nil
+	default:
+		return [2]int{}, fmt.Errorf("unknown mode %d", opts.Mode)
+	}
+}
+
+// ratioPoints calculates the points outside the pair where the far antenna is num/den times as far away as the near one.
+// Beyond b that point is b + (b - a) * den / (num - den), and it only counts when it lands on the grid lattice
+func ratioPoints(a, b Position, ratio [2]int) []Position {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	num, den := ratio[0], ratio[1]
+	scale := num - den
+
+	if (dx*den)%scale != 0 || (dy*den)%scale != 0 {
+		return nil
+	}
+	stepX, stepY := dx*den/scale, dy*den/scale
+	return []Position{
+		{a.X - stepX, a.Y - stepY},
+		{b.X + stepX, b.Y + stepY},
+	}
+}
+
+// harmonics walks the line through the pair in both directions. The step is reduced by the gcd of dx and dy,
+// so lattice points between the antennas (and between multiples of their distance) aren't skipped
+func harmonics(a, b Position, inBounds func(Position) bool) []Position {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	divisor := gcd(abs(dx), abs(dy))
+	dx, dy = dx/divisor, dy/divisor
+
+	points := make([]Position, 0)
+	for p := a; inBounds(p); p = (Position{p.X + dx, p.Y + dy}) {
+		points = append(points, p)
+	}
+	for p := (Position{a.X - dx, a.Y - dy}); inBounds(p); p = (Position{p.X - dx, p.Y - dy}) {
+		points = append(points, p)
+	}
+	return points
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
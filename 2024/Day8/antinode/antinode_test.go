@@ -0,0 +1,114 @@
+package antinode
+
+import (
+	"strings"
+	"testing"
+)
+
+var example = []string{
+	"............",
+	"........0...",
+	".....0......",
+	".......0....",
+	"....0.......",
+	"......A.....",
+	"............",
+	"............",
+	"........A...",
+	".........A..",
+	"............",
+	"............",
+}
+
+func toGrid(lines []string) [][]string {
+	grid := make([][]string, 0)
+	for _, line := range lines {
+		grid = append(grid, strings.Split(line, ""))
+	}
+	return grid
+}
+
+func TestFind_Example(t *testing.T) {
+	grid := toGrid(example)
+
+	exact, err := Find(grid, Options{Mode: Exact})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(Unique(exact)); got != 14 {
+		t.Errorf("Expected 14 exact antinodes, got %d", got)
+	}
+
+	harmonics, err := Find(grid, Options{Mode: Harmonics})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(Unique(harmonics)); got != 34 {
+		t.Errorf("Expected 34 harmonic antinodes, got %d", got)
+	}
+}
+
+// TestFind_HarmonicsReducesByGCD checks the points between two antennas that are (2, 4) apart are found
+func TestFind_HarmonicsReducesByGCD(t *testing.T) {
+	grid := toGrid([]string{
+		"a....",
+		".....",
+		".....",
+		".....",
+		"..a..",
+	})
+
+	harmonics, err := Find(grid, Options{Mode: Harmonics})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unique := Unique(harmonics)
+	for _, p := range []Position{{0, 0}, {1, 2}, {2, 4}} {
+		if !unique[p] {
+			t.Errorf("Expected antinode at %v", p)
+		}
+	}
+	if len(unique) != 3 {
+		t.Errorf("Expected 3 antinodes, got %v", unique)
+	}
+}
+
+func TestFind_Options(t *testing.T) {
+	grid := toGrid(example)
+
+	onlyA, err := Find(grid, Options{Mode: Exact, Frequencies: []string{"A"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := onlyA["0"]; ok || len(onlyA["A"]) == 0 {
+		t.Errorf("Expected only frequency A, got %v", onlyA)
+	}
+
+	unbounded, err := Find(grid, Options{Mode: Exact, Unbounded: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 4 zeros and 3 A's give 6 + 3 pairs with 2 antinodes each
+	total := 0
+	for _, found := range unbounded {
+		total += len(found)
+	}
+	if total != 18 {
+		t.Errorf("Expected 18 unbounded antinodes, got %d", total)
+	}
+
+	if _, err := Find(grid, Options{Mode: Harmonics, Unbounded: true}); err == nil {
+		t.Error("Expected error for unbounded harmonics")
+	}
+	if _, err := Find(grid, Options{Mode: Ratio, Ratio: [2]int{1, 1}}); err == nil {
+		t.Error("Expected error for a 1:1 ratio")
+	}
+
+	threeToOne, err := Find(toGrid([]string{"b.b.."}), Options{Mode: Ratio, Ratio: [2]int{3, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found := threeToOne["b"]; len(found) != 1 || found[0].Position != (Position{3, 0}) {
+		t.Errorf("Expected a single 3:1 antinode at (3, 0), got %v", found)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/puzzle"
+	"2024/util"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part1(util.TransformStringSliceInto2DMatrix(input))
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(util.TransformStringSliceInto2DMatrix(input))
+	}
+}
+
+func FuzzCharGrid(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		grid, err := util.CharGrid(lines)
+		if err != nil {
+			return
+		}
+		if len(grid) != len(lines) {
+			t.Fatalf("Expected a row per line, got %d rows for %d lines", len(grid), len(lines))
+		}
+		// every antinode is a cell of the map, and the harmonics include the antinodes of part 1
+		cells := len(grid) * len(grid[0])
+		antinodes, harmonics := part1(grid), part2(grid)
+		if antinodes > harmonics || harmonics > cells {
+			t.Errorf("Expected %d antinodes <= %d harmonics <= %d cells", antinodes, harmonics, cells)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		grid, err := util.CharGrid(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if example.Part == 2 {
+			return part2(grid)
+		}
+		return part1(grid)
+	})
+}
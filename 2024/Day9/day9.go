@@ -1,9 +1,11 @@
 package main
 
 import (
+	"2024/solution"
 	"2024/util"
 	"fmt"
-	"strings"
+	"log/slog"
+	"os"
 )
 
 // indexSpace is a struct representation of a decompressed position in the disk
@@ -24,22 +26,28 @@ type indexSpace struct {
 
 func main() {
 	input := util.ReadInput(util.Parameter())
-	decompressedInput, fileNum := decompress(input)
+	decompressedInput, fileNum, err := decompress(input)
+	if err != nil {
+		slog.Error("Unable to read the disk map", "err", err)
+		os.Exit(1)
+	}
 	copy1 := make([]indexSpace, len(decompressedInput))
 	copy2 := make([]indexSpace, len(decompressedInput))
 	copy(copy1, decompressedInput)
 	copy(copy2, decompressedInput)
-	part1(copy1)
-	part2(copy2, fileNum)
+	sol := solution.New(9)
+	sol.Part(1, func() any { return part1(copy1) })
+	sol.Part(2, func() any { return part2(copy2, fileNum) })
 }
 
 // part1 is the solution to part1, will swap right most filled spot with left most free
-func part1(input []indexSpace) {
+func part1(input []indexSpace) int {
 
 	left := findNextEmpty(input, 0)
 	right := findNextFilled(input, len(input)-1)
 
-	for left < right {
+	// A disk without any free space has nothing to compact
+	for left != -1 && left < right {
 		input[left], input[right] = input[right], input[left]
 		left = findNextEmpty(input, left+1)
 		right = findNextFilled(input, right-1)
@@ -54,12 +62,11 @@ func part1(input []indexSpace) {
 		sum += index * file.fileNum
 	}
 
-	fmt.Println("(part1) Ans: ", sum)
-
+	return sum
 }
 
 // part2 solves part 2 by group files on the right and attempting to find suitable free spans starting from the left
-func part2(input []indexSpace, fileNum int) {
+func part2(input []indexSpace, fileNum int) int {
 
 	// Group indices by fileNum to avoid repeated iteration
 	fileBlocks := make(map[int][]int)
@@ -110,8 +117,7 @@ func part2(input []indexSpace, fileNum int) {
 		}
 	}
 
-	fmt.Println("(part2) Ans: ", sum)
-
+	return sum
 }
 
 // findNextFilled is a part 1 helper function, finds the next filled space from the given index (starting from the end of the slice)
@@ -137,15 +143,20 @@ func findNextEmpty(input []indexSpace, start int) int {
 // decompress unpacks the initial disk map, it has a pattern of being (filled, unfilled) where the number represents the size
 // Then each filled file is given an file ID
 // So 123 => 0..111
-func decompress(input []string) ([]indexSpace, int) {
+// The disk map has to be a single line of digits
+func decompress(input []string) ([]indexSpace, int, error) {
+	if len(input) != 1 || input[0] == "" {
+		return nil, 0, fmt.Errorf("expected the disk map on a single line, found %d lines", len(input))
+	}
 
 	decompressed := make([]indexSpace, 0)
-	split := strings.Split(input[0], "")
 	fileIndex := 0
 	used := 1
-	for index := 0; index < len(split); index += 1 {
-
-		space := util.ParseInt(split[index])
+	for index, char := range input[0] {
+		if char < '0' || char > '9' {
+			return nil, 0, fmt.Errorf("%q at %d is not a digit", char, index)
+		}
+		space := int(char - '0')
 
 		if used%2 != 0 {
 			toAdd := indexSpace{false, fileIndex}
@@ -161,5 +172,5 @@ func decompress(input []string) ([]indexSpace, int) {
 		}
 		used += 1
 	}
-	return decompressed, fileIndex
+	return decompressed, fileIndex, nil
 }
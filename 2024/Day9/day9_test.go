@@ -0,0 +1,118 @@
+package main
+
+import (
+	"2024/bench"
+	"2024/fuzztest"
+	"2024/gen"
+	"2024/puzzle"
+	"slices"
+	"testing"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	input := bench.Input(b, 9)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		disk, _ := diskMap(b, input)
+		part1(disk)
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	input := bench.Input(b, 9)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		part2(diskMap(b, input))
+	}
+}
+
+func diskMap(tb testing.TB, input []string) ([]indexSpace, int) {
+	disk, fileNum, err := decompress(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return disk, fileNum
+}
+
+// compactBlocks moves file blocks one at a time from the end of the disk into the leftmost free block
+func compactBlocks(blocks []int) []int {
+	blocks = slices.Clone(blocks)
+	left, right := 0, len(blocks)-1
+	for {
+		for left < len(blocks) && blocks[left] != -1 {
+			left++
+		}
+		for right >= 0 && blocks[right] == -1 {
+			right--
+		}
+		if left >= right {
+			return blocks
+		}
+		blocks[left], blocks[right] = blocks[right], blocks[left]
+	}
+}
+
+// compactFiles tries to move every file once, highest ID first, into the leftmost free span to its left that fits
+func compactFiles(blocks []int) []int {
+	blocks = slices.Clone(blocks)
+	for id := slices.Max(blocks); id >= 0; id-- {
+		start := slices.Index(blocks, id)
+		size := 0
+		for start+size < len(blocks) && blocks[start+size] == id {
+			size++
+		}
+		for free := 0; free+size <= start; free++ {
+			if slices.ContainsFunc(blocks[free:free+size], func(b int) bool { return b != -1 }) {
+				continue
+			}
+			for i := 0; i < size; i++ {
+				blocks[free+i], blocks[start+i] = id, -1
+			}
+			break
+		}
+	}
+	return blocks
+}
+
+func TestProperty_Compaction(t *testing.T) {
+	for seed := uint64(0); seed < 200; seed++ {
+		disk := gen.NewDiskMap(gen.New(seed), 1+int(seed%40))
+
+		blocks, fileNum := diskMap(t, []string{disk.Line})
+		if got, want := part1(blocks), gen.Checksum(compactBlocks(disk.Blocks)); got != want {
+			t.Fatalf("Seed %d: part 1 gave %d for %s, moving blocks one by one gives %d", seed, got, disk.Line, want)
+		}
+
+		blocks, fileNum = diskMap(t, []string{disk.Line})
+		if got, want := part2(blocks, fileNum), gen.Checksum(compactFiles(disk.Blocks)); got != want {
+			t.Fatalf("Seed %d: part 2 gave %d for %s, moving whole files one by one gives %d", seed, got, disk.Line, want)
+		}
+	}
+}
+
+func FuzzDecompress(f *testing.F) {
+	fuzztest.FuzzLines(f, func(t *testing.T, lines []string) {
+		disk, fileNum, err := decompress(lines)
+		if err != nil {
+			return
+		}
+		// every digit is that many blocks, alternating between a file and free space
+		blocks := 0
+		for _, digit := range lines[0] {
+			blocks += int(digit - '0')
+		}
+		if len(disk) != blocks || fileNum != (len(lines[0])+1)/2 {
+			t.Errorf("Expected %d blocks and %d files, got %d and %d", blocks, (len(lines[0])+1)/2, len(disk), fileNum)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	puzzle.CheckExamples(t, "testdata", func(example puzzle.Example, input []string) any {
+		disk, fileNum := diskMap(t, input)
+		if example.Part == 2 {
+			return part2(disk, fileNum)
+		}
+		return part1(disk)
+	})
+}
@@ -0,0 +1,210 @@
+package bench
+
+import (
+	"2024/util"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// InputDirEnv overrides the directory puzzle inputs are read from, by default the inputs directory of the module
+const InputDirEnv = util.InputDirEnv
+
+// Result is a single benchmark line from go test -bench
+//   - Package: import path of the package the benchmark lives in
+//   - Day: puzzle day taken from the package name, 0 if the package isn't a day
+//   - Name: benchmark name without the Benchmark prefix and the GOMAXPROCS suffix
+//   - Iterations: number of times the benchmark ran
+//   - NsPerOp, BytesPerOp, AllocsPerOp: the measurements, the last two need -benchmem
+type Result struct {
+	Package     string  `json:"package"`
+	Day         int     `json:"day"`
+	Name        string  `json:"name"`
+	Iterations  int     `json:"iterations"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+}
+
+// Key identifies the benchmark across runs
+func (r Result) Key() string {
+	return r.Package + "." + r.Name
+}
+
+var (
+	benchLine  = regexp.MustCompile(`^Benchmark(\S+?)(?:-\d+)?\s+(\d+)\s+(.*)$`)
+	dayPackage = regexp.MustCompile(`/Day(\d+)$`)
+)
+
+// Parse reads the output of go test -bench -benchmem. The package of every benchmark comes from the pkg: line go test
+// prints before the benchmarks of each package, anything that isn't a benchmark line is skipped
+func Parse(r io.Reader) ([]Result, error) {
+	results := make([]Result, 0)
+	pkg := ""
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "pkg: ") {
+			pkg = strings.TrimPrefix(line, "pkg: ")
+			continue
+		}
+
+		groups := benchLine.FindStringSubmatch(line)
+		if groups == nil {
+			continue
+		}
+		result := Result{Package: pkg, Name: groups[1]}
+		result.Iterations, _ = strconv.Atoi(groups[2])
+		if day := dayPackage.FindStringSubmatch(pkg); day != nil {
+			result.Day, _ = strconv.Atoi(day[1])
+		}
+
+		// measurements come in value unit pairs, like 1234 ns/op 56 B/op 7 allocs/op
+		fields := strings.Fields(groups[3])
+		for i := 0; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid measurement %q in %q", fields[i], line)
+			}
+			switch fields[i+1] {
+			case "ns/op":
+				result.NsPerOp = value
+			case "B/op":
+				result.BytesPerOp = int64(value)
+			case "allocs/op":
+				result.AllocsPerOp = int64(value)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, scanner.Err()
+}
+
+// Comparison is a benchmark next to its baseline
+//   - Baseline: the saved result, nil if the benchmark is new
+//   - Delta: change in ns/op relative to the baseline, 0.25 means 25% slower
+//   - Regression: the benchmark got slower by more than the threshold
+type Comparison struct {
+	Result
+	Baseline   *Result
+	Delta      float64
+	Regression bool
+}
+
+// Compare matches every result against the baseline and flags the ones that got slower by more than threshold,
+// given as a fraction (0.1 is 10%)
+func Compare(current, baseline []Result, threshold float64) []Comparison {
+	saved := make(map[string]Result)
+	for _, result := range baseline {
+		saved[result.Key()] = result
+	}
+
+	comparisons := make([]Comparison, 0, len(current))
+	for _, result := range current {
+		comparison := Comparison{Result: result}
+		if old, ok := saved[result.Key()]; ok {
+			comparison.Baseline = &old
+			if old.NsPerOp > 0 {
+				comparison.Delta = (result.NsPerOp - old.NsPerOp) / old.NsPerOp
+				comparison.Regression = comparison.Delta > threshold
+			}
+		}
+		comparisons = append(comparisons, comparison)
+	}
+	return comparisons
+}
+
+// LoadBaseline reads saved results, a missing baseline is not an error and gives no results
+func LoadBaseline(path string) ([]Result, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Result{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0)
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+	return results, nil
+}
+
+// Merge returns the baseline with the results in it, a result replaces the saved one with the same Key and is added
+// at the end if there is none. Saving a run of a few days keeps what the baseline holds for the others
+func Merge(baseline, results []Result) []Result {
+	index := make(map[string]int, len(baseline))
+	merged := make([]Result, 0, len(baseline)+len(results))
+	for _, result := range baseline {
+		index[result.Key()] = len(merged)
+		merged = append(merged, result)
+	}
+	for _, result := range results {
+		if i, ok := index[result.Key()]; ok {
+			merged[i] = result
+			continue
+		}
+		index[result.Key()] = len(merged)
+		merged = append(merged, result)
+	}
+	return merged
+}
+
+// SaveBaseline writes the results so later runs can compare against them
+func SaveBaseline(path string, results []Result) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Input reads the puzzle input for a day as lines, skipping the benchmark if the input isn't there since inputs aren't
+// checked in. Inputs are read from dayN.txt in the directory set by AOC_INPUT_DIR, or the inputs directory of the module
+func Input(tb testing.TB, day int) []string {
+	tb.Helper()
+	return YearInput(tb, 0, day)
+}
+
+// YearInput is Input for a day of another year, those inputs are read from a directory named after the year
+// inside the inputs directory
+func YearInput(tb testing.TB, year, day int) []string {
+	tb.Helper()
+	data, err := os.ReadFile(inputPath(tb, year, day))
+	if err != nil {
+		tb.Fatalf("Unable to read input for day %d: %v", day, err)
+	}
+	return strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+}
+
+// InputPath finds the puzzle input for a day, for solvers that open the file themselves. Skips the benchmark if the
+// input isn't there
+func InputPath(tb testing.TB, day int) string {
+	tb.Helper()
+	return inputPath(tb, 0, day)
+}
+
+func inputPath(tb testing.TB, year, day int) string {
+	tb.Helper()
+	path, err := util.InputFile(year, day)
+	if err != nil {
+		tb.Skipf("No input for day %d: %v", day, err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		tb.Skipf("No input for day %d at %s, set %s to point somewhere else", day, path, InputDirEnv)
+	}
+	return path
+}
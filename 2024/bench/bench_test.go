@@ -0,0 +1,140 @@
+package bench
+
+import (
+	"2024/util"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const output = `goos: linux
+goarch: amd64
+pkg: 2024/Day2
+cpu: Some CPU @ 2.00GHz
+BenchmarkPart1-8   	     100	      6179 ns/op	    2328 B/op	      50 allocs/op
+BenchmarkPart2-8   	     100	      4820 ns/op	    2344 B/op	      52 allocs/op
+PASS
+ok  	2024/Day2	0.010s
+goos: linux
+goarch: amd64
+pkg: 2024/Day12
+BenchmarkPart1   	      10	  58086.5 ns/op
+PASS
+ok  	2024/Day12	0.011s
+`
+
+func TestParse(t *testing.T) {
+	results, err := Parse(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []Result{
+		{Package: "2024/Day2", Day: 2, Name: "Part1", Iterations: 100, NsPerOp: 6179, BytesPerOp: 2328, AllocsPerOp: 50},
+		{Package: "2024/Day2", Day: 2, Name: "Part2", Iterations: 100, NsPerOp: 4820, BytesPerOp: 2344, AllocsPerOp: 52},
+		{Package: "2024/Day12", Day: 12, Name: "Part1", Iterations: 10, NsPerOp: 58086.5},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("Expected %d results, got %+v", len(want), results)
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("Expected %+v, got %+v", want[i], results[i])
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []Result{
+		{Package: "2024/Day1", Name: "Part1", NsPerOp: 100},
+		{Package: "2024/Day1", Name: "Part2", NsPerOp: 100},
+	}
+	current := []Result{
+		{Package: "2024/Day1", Name: "Part1", NsPerOp: 105},
+		{Package: "2024/Day1", Name: "Part2", NsPerOp: 150},
+		{Package: "2024/Day3", Name: "Part1", NsPerOp: 10},
+	}
+
+	comparisons := Compare(current, baseline, 0.1)
+	if comparisons[0].Regression || comparisons[0].Delta < 0.049 || comparisons[0].Delta > 0.051 {
+		t.Errorf("Expected a 5%% slowdown under the threshold, got %+v", comparisons[0])
+	}
+	if !comparisons[1].Regression {
+		t.Errorf("Expected a 50%% slowdown to be a regression, got %+v", comparisons[1])
+	}
+	if comparisons[2].Baseline != nil || comparisons[2].Regression {
+		t.Errorf("Expected a new benchmark without a baseline, got %+v", comparisons[2])
+	}
+}
+
+func TestBaseline_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "baseline.json")
+
+	results, err := LoadBaseline(path)
+	if err != nil || len(results) != 0 {
+		t.Fatalf("Expected a missing baseline to be empty, got %v, %v", results, err)
+	}
+
+	saved := []Result{{Package: "2024/Day5", Day: 5, Name: "Part2", Iterations: 3, NsPerOp: 42, BytesPerOp: 8, AllocsPerOp: 1}}
+	if err := SaveBaseline(path, saved); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	results, err = LoadBaseline(path)
+	if err != nil || len(results) != 1 || results[0] != saved[0] {
+		t.Errorf("Expected %+v back, got %+v, %v", saved, results, err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	baseline := []Result{
+		{Package: "2024/Day2", Day: 2, Name: "Part1", NsPerOp: 10},
+		{Package: "2024/Day5", Day: 5, Name: "Part1", NsPerOp: 20},
+	}
+	results := []Result{
+		{Package: "2024/Day5", Day: 5, Name: "Part1", NsPerOp: 25},
+		{Package: "2024/Day5", Day: 5, Name: "Part2", NsPerOp: 30},
+	}
+
+	merged := Merge(baseline, results)
+	if len(merged) != 3 || merged[0] != baseline[0] || merged[1] != results[0] || merged[2] != results[1] {
+		t.Errorf("Expected day 2 kept, day 5 part 1 replaced and part 2 added, got %+v", merged)
+	}
+	if baseline[1].NsPerOp != 20 {
+		t.Error("Expected the baseline passed in to be left alone")
+	}
+}
+
+func TestInput(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "day7.txt"), []byte("190: 10 19\n3267: 81 40 27\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(InputDirEnv, dir)
+
+	lines := Input(t, 7)
+	if len(lines) != 2 || lines[1] != "3267: 81 40 27" {
+		t.Errorf("Unexpected input %q", lines)
+	}
+}
+
+func TestYearInput(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "2025"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "2025", "day1.txt"), []byte("L68\nR48\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(InputDirEnv, dir)
+
+	lines := YearInput(t, 2025, 1)
+	if len(lines) != 2 || lines[0] != "L68" {
+		t.Errorf("Unexpected input %q", lines)
+	}
+
+	path, err := util.InputFile(0, 1)
+	if err != nil || path != filepath.Join(dir, "day1.txt") {
+		t.Errorf("Expected this year's input right in the inputs directory, got %s, %v", path, err)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"2024/bench"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// runBench runs go test -bench over the day packages, prints ns/op and allocs/op for every part next to the baseline
+// and exits with 1 if anything regressed past the threshold
+func runBench(args []string) int {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	day := flags.Int("day", 0, "Only benchmark this day, 0 benchmarks every day")
+	baselinePath := flags.String("baseline", filepath.Join("bench", "baseline.json"), "File holding the saved baseline")
+	save := flags.Bool("save", false, "Save the results as the new baseline")
+	threshold := flags.Float64("threshold", 10, "Percent slowdown in ns/op that counts as a regression")
+	benchtime := flags.String("benchtime", "", "Passed to go test -benchtime")
+	flags.Parse(args)
+
+	packages, err := dayPackages(*day)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to find days:", err)
+		return 1
+	}
+
+	goArgs := []string{"test", "-run", "^$", "-bench", ".", "-benchmem"}
+	if *benchtime != "" {
+		goArgs = append(goArgs, "-benchtime", *benchtime)
+	}
+	goArgs = append(goArgs, packages...)
+
+	// go test output is kept to be parsed, and also streamed to stderr so long runs show progress
+	var output bytes.Buffer
+	cmd := exec.Command("go", goArgs...)
+	cmd.Stdout = io.MultiWriter(&output, os.Stderr)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "Benchmarks failed:", err)
+		return 1
+	}
+
+	results, err := bench.Parse(&output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to read benchmark output:", err)
+		return 1
+	}
+	baseline, err := bench.LoadBaseline(*baselinePath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to load baseline:", err)
+		return 1
+	}
+
+	comparisons := bench.Compare(results, baseline, *threshold/100)
+	regressions := printComparisons(os.Stdout, comparisons)
+
+	if *save {
+		// only the benchmarks that ran are replaced, the baseline keeps the days that didn't run this time
+		merged := bench.Merge(baseline, results)
+		if err := bench.SaveBaseline(*baselinePath, merged); err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to save baseline:", err)
+			return 1
+		}
+		fmt.Printf("Saved %d results to %s, it now holds %d\n", len(results), *baselinePath, len(merged))
+	}
+	if regressions > 0 {
+		fmt.Printf("%d benchmarks regressed by more than %.0f%%\n", regressions, *threshold)
+		return 1
+	}
+	return 0
+}
+
+// printComparisons writes the results as a table ordered by day, returns the number of regressions
+func printComparisons(out io.Writer, comparisons []bench.Comparison) int {
+	sort.SliceStable(comparisons, func(i, j int) bool {
+		if comparisons[i].Day != comparisons[j].Day {
+			return comparisons[i].Day < comparisons[j].Day
+		}
+		return comparisons[i].Name < comparisons[j].Name
+	})
+
+	regressions := 0
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "Day\tBenchmark\tns/op\tB/op\tallocs/op\tbaseline ns/op\tdelta\t\t")
+	for _, c := range comparisons {
+		baseline, delta, note := "-", "new", ""
+		if c.Baseline != nil {
+			baseline = strconv.FormatFloat(c.Baseline.NsPerOp, 'f', 0, 64)
+			delta = fmt.Sprintf("%+.1f%%", c.Delta*100)
+		}
+		if c.Regression {
+			note = "REGRESSION"
+			regressions++
+		}
+		fmt.Fprintf(table, "%d\t%s\t%.0f\t%d\t%d\t%s\t%s\t%s\t\n", c.Day, c.Name, c.NsPerOp, c.BytesPerOp, c.AllocsPerOp, baseline, delta, note)
+	}
+	table.Flush()
+	return regressions
+}
+
+// dayPackages lists the day packages to benchmark, in day order
+func dayPackages(day int) ([]string, error) {
+	if day != 0 {
+		dir := fmt.Sprintf("Day%d", day)
+		if _, err := os.Stat(dir); err != nil {
+			return nil, fmt.Errorf("no package for day %d: %w", day, err)
+		}
+		return []string{"./" + dir}, nil
+	}
+
+	dirs, err := filepath.Glob("Day*")
+	if err != nil {
+		return nil, err
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no Day directories, aoc has to run from the module root")
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		a, _ := strconv.Atoi(strings.TrimPrefix(dirs[i], "Day"))
+		b, _ := strconv.Atoi(strings.TrimPrefix(dirs[j], "Day"))
+		return a < b
+	})
+
+	packages := make([]string, len(dirs))
+	for i, dir := range dirs {
+		packages[i] = "./" + dir
+	}
+	return packages, nil
+}
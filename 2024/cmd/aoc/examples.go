@@ -0,0 +1,90 @@
+package main
+
+import (
+	"2024/puzzle"
+	"2024/util"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// runExamples pulls the examples and their answers out of a puzzle page and writes them as fixtures into the
+// testdata directory of the day
+func runExamples(args []string) int {
+	flags := flag.NewFlagSet("examples", flag.ExitOnError)
+	year := flags.Int("year", util.ModuleYear(), "Year of the puzzle")
+	day := flags.Int("day", 0, "Day of the puzzle, 1 to 25")
+	pagePath := flags.String("page", "", "Saved puzzle page to read, by default the cached page of the day")
+	refresh := flags.Bool("refresh", false, "Download the page again, needed to see part 2 after solving part 1")
+	force := flags.Bool("force", false, "Overwrite fixtures that are already in testdata")
+	flags.Parse(args)
+
+	if *year == 0 || *day < 1 || *day > 25 {
+		fmt.Fprintln(os.Stderr, "A -year and a -day from 1 to 25 are needed")
+		return 2
+	}
+
+	module, err := util.ModulePath(".")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to read the module path, aoc has to run from the module root:", err)
+		return 1
+	}
+	dir := dayDir(module, *year, *day)
+	if _, err := os.Stat(dir); err != nil {
+		fmt.Fprintf(os.Stderr, "No package for day %d at %s\n", *day, dir)
+		return 1
+	}
+
+	page, err := loadPage(*year, *day, *pagePath, *refresh)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to read the puzzle page:", err)
+		return 1
+	}
+	parsed, err := puzzle.ParsePage(page)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to read the puzzle page:", err)
+		return 1
+	}
+
+	testdata := filepath.Join(dir, "testdata")
+	examples, err := puzzle.WriteFixtures(testdata, parsed, *force)
+	if errors.Is(err, os.ErrExist) {
+		fmt.Fprintln(os.Stderr, "The fixtures are already there, run again with -force to overwrite them:", err)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to write the fixtures:", err)
+		return 1
+	}
+
+	fmt.Printf("%s: wrote %s\n", parsed.Title, filepath.Join(testdata, puzzle.ExamplesFile))
+	for _, example := range examples {
+		fmt.Printf("  part %d: %s should give %s\n", example.Part, example.Input, example.Answer)
+	}
+	if len(parsed.Parts) < 2 {
+		fmt.Println("The page only has part 1, run again with -refresh -force once it is solved")
+	}
+	fmt.Println("The example of each part is a guess, check it against the page")
+	return 0
+}
+
+// loadPage reads the page from a file, or from the fetcher's cache downloading it if needed
+func loadPage(year, day int, path string, refresh bool) (string, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		return string(data), err
+	}
+
+	fetcher, err := util.NewFetcher()
+	if err != nil {
+		return "", err
+	}
+	if refresh {
+		if err := fetcher.DownloadPage(year, day); err != nil {
+			return "", err
+		}
+	}
+	return fetcher.Page(year, day)
+}
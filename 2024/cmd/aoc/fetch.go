@@ -0,0 +1,40 @@
+package main
+
+import (
+	"2024/util"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// runFetch downloads the input for a day into the inputs directory, skipping the download if it is already there
+func runFetch(args []string) int {
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	year := flags.Int("year", util.ModuleYear(), "Year of the puzzle")
+	day := flags.Int("day", 0, "Day of the puzzle, 1 to 25")
+	force := flags.Bool("force", false, "Download the input even if it is already saved")
+	flags.Parse(args)
+
+	if *year == 0 || *day < 1 || *day > 25 {
+		fmt.Fprintln(os.Stderr, "A -year and a -day from 1 to 25 are needed")
+		return 2
+	}
+
+	fetcher, err := util.NewFetcher()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to set up the fetcher:", err)
+		return 1
+	}
+	path := fetcher.Path(*year, *day)
+	if _, err := os.Stat(path); err == nil && !*force {
+		fmt.Println("Already saved at", path)
+		return 0
+	}
+
+	if err := fetcher.Download(*year, *day); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to fetch the input:", err)
+		return 1
+	}
+	fmt.Println("Saved to", path)
+	return 0
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+/*
+	aoc is the toolbox for working on the puzzles, run it from the module root:
+
+		go run ./cmd/aoc <command> [flags]
+
+	Every command parses its own flags, run a command with -h to see them
+*/
+
+// command is a subcommand of aoc
+//   - summary: one line description shown in the usage
+//   - run: runs the command with the arguments after its name, returns the exit code
+type command struct {
+	summary string
+	run     func(args []string) int
+}
+
+var commands = map[string]command{
+	"bench":    {summary: "run the benchmarks of every day and compare them against a saved baseline", run: runBench},
+	"examples": {summary: "write the examples and answers from the puzzle page as test fixtures of a day", run: runExamples},
+	"fetch":    {summary: "download the input for a day into the inputs directory", run: runFetch},
+	"new":      {summary: "create the package for a new day and register it with the runner", run: runNew},
+	"run":      {summary: "solve a day created with new", run: runRun},
+	"submit":   {summary: "submit the answer to a part, unless the answer history says it is wrong", run: runSubmit},
+	"vm":       {summary: "step through a day 17 program in the 3-bit computer debugger", run: runVM},
+	"watch":    {summary: "rerun the tests and the real input of a day every time its code or input changes", run: runWatch},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	os.Exit(cmd.run(os.Args[2:]))
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: aoc <command> [flags]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-9s %s\n", name, commands[name].summary)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"2024/util"
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// registryFile imports every day made by aoc new so the runner knows about them, it is rewritten for every new day
+var registryFile = filepath.Join("cmd", "aoc", "days.go")
+
+// scaffold is what the templates are filled in with
+//   - Module: module path, the generated code imports solution and bench from it
+//   - Year, Day: the puzzle
+//   - Package: package name of the day
+type scaffold struct {
+	Module  string
+	Year    int
+	Day     int
+	Package string
+}
+
+// runNew creates the package for a new day with a solver stub, an example test and benchmarks, and registers it
+// with the runner
+func runNew(args []string) int {
+	flags := flag.NewFlagSet("new", flag.ExitOnError)
+	year := flags.Int("year", 0, "Year of the puzzle")
+	day := flags.Int("day", 0, "Day of the puzzle, 1 to 25")
+	flags.Parse(args)
+
+	dir, err := newDay(".", *year, *day)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to create the day:", err)
+		return 1
+	}
+	fmt.Println("Created", dir)
+	fmt.Printf("Run the day with: go run ./cmd/aoc run -year %d -day %d, the input is downloaded the first time\n", *year, *day)
+	return 0
+}
+
+// newDay writes the skeleton of a day into the module at root and adds it to the registry, returning the directory
+// of the day relative to root. Days of the module's own year go at the root like the rest, other years get a
+// directory of their own
+func newDay(root string, year, day int) (string, error) {
+	if year < 2015 {
+		return "", fmt.Errorf("invalid year %d, Advent of Code started in 2015", year)
+	}
+	if day < 1 || day > 25 {
+		return "", fmt.Errorf("invalid day %d, expected 1 to 25", day)
+	}
+
+	module, err := util.ModulePath(root)
+	if err != nil {
+		return "", fmt.Errorf("%w, aoc has to run from the module root", err)
+	}
+	dir := dayDir(module, year, day)
+	if _, err := os.Stat(filepath.Join(root, dir)); err == nil {
+		return "", fmt.Errorf("%s already exists", dir)
+	}
+
+	data := scaffold{Module: module, Year: year, Day: day, Package: fmt.Sprintf("day%d", day)}
+	if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+		return "", err
+	}
+	files := map[string]string{
+		fmt.Sprintf("day%d.go", day):      "day.go.tmpl",
+		fmt.Sprintf("day%d_test.go", day): "day_test.go.tmpl",
+	}
+	for name, tmpl := range files {
+		if err := writeTemplate(filepath.Join(root, dir, name), tmpl, data); err != nil {
+			return "", err
+		}
+	}
+
+	if err := register(root, module+"/"+filepath.ToSlash(dir)); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// register adds the import path of a day to the registry file, keeping the days that are already there
+func register(root string, importPath string) error {
+	path := filepath.Join(root, registryFile)
+	imports := []string{importPath}
+
+	if _, err := os.Stat(path); err == nil {
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+		if err != nil {
+			return fmt.Errorf("invalid registry %s: %w", path, err)
+		}
+		for _, spec := range file.Imports {
+			existing, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return err
+			}
+			if existing != importPath {
+				imports = append(imports, existing)
+			}
+		}
+	}
+	sort.Strings(imports)
+
+	return writeTemplate(path, "days.go.tmpl", imports)
+}
+
+// writeTemplate fills in a template and gofmts the result before writing it, so a broken template fails here
+// instead of in the build
+func writeTemplate(path string, name string, data any) error {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return err
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s generated invalid Go: %w", name, err)
+	}
+	return os.WriteFile(path, source, 0o644)
+}
+
+// dayDir is the directory of a day relative to the module root
+func dayDir(module string, year, day int) string {
+	if module == strconv.Itoa(year) {
+		return fmt.Sprintf("Day%d", day)
+	}
+	return filepath.Join(strconv.Itoa(year), fmt.Sprintf("Day%d", day))
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNewDay(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "cmd", "aoc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module 2024\n\ngo 1.23.4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, day := range []int{3, 1} {
+		dir, err := newDay(root, 2025, day)
+		if err != nil {
+			t.Fatalf("Unexpected error for day %d: %v", day, err)
+		}
+		if want := filepath.Join("2025", "Day"+strconv.Itoa(day)); dir != want {
+			t.Errorf("Expected %s, got %s", want, dir)
+		}
+	}
+
+	for _, name := range []string{"day1.go", "day1_test.go"} {
+		source, err := os.ReadFile(filepath.Join(root, "2025", "Day1", name))
+		if err != nil {
+			t.Fatalf("Expected %s to be created: %v", name, err)
+		}
+		if !strings.HasPrefix(string(source), "package day1\n") {
+			t.Errorf("Expected %s to be in package day1", name)
+		}
+	}
+	day1, _ := os.ReadFile(filepath.Join(root, "2025", "Day1", "day1.go"))
+	if !strings.Contains(string(day1), "solution.Register(2025, 1,") {
+		t.Errorf("Expected day 1 to register itself, got\n%s", day1)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(root, registryFile), nil, parser.ImportsOnly)
+	if err != nil {
+		t.Fatalf("Invalid registry: %v", err)
+	}
+	if len(file.Imports) != 2 || file.Imports[0].Path.Value != `"2024/2025/Day1"` || file.Imports[1].Path.Value != `"2024/2025/Day3"` {
+		t.Errorf("Expected both days in the registry in order, got %d imports", len(file.Imports))
+	}
+
+	if _, err := newDay(root, 2025, 1); err == nil {
+		t.Errorf("Expected an error for a day that already exists")
+	}
+	if _, err := newDay(root, 2025, 26); err == nil {
+		t.Errorf("Expected an error for day 26")
+	}
+	if _, err := newDay(root, 2014, 1); err == nil {
+		t.Errorf("Expected an error for a year before Advent of Code")
+	}
+}
+
+func TestDayDir(t *testing.T) {
+	if dir := dayDir("2024", 2024, 5); dir != "Day5" {
+		t.Errorf("Expected days of the module's year at the root, got %s", dir)
+	}
+	if dir := dayDir("2024", 2023, 5); dir != filepath.Join("2023", "Day5") {
+		t.Errorf("Expected days of other years in their own directory, got %s", dir)
+	}
+}
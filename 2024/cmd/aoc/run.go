@@ -0,0 +1,47 @@
+package main
+
+import (
+	"2024/solution"
+	"2024/util"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// runRun solves a day registered with the runner and prints its answers
+func runRun(args []string) int {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	year := flags.Int("year", 0, "Year of the puzzle")
+	day := flags.Int("day", 0, "Day of the puzzle")
+	file := flags.String("file", "", "Puzzle input, by default the saved input of the day, downloaded if it isn't saved yet")
+	format := flags.String("format", solution.Text, "How answers are printed: text, json (one object per line) or tsv")
+	flags.Parse(args)
+
+	solver, ok := solution.Lookup(*year, *day)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Day %d of %d isn't registered, create it with aoc new\n", *day, *year)
+		return 1
+	}
+
+	var input []string
+	if *file != "" {
+		if _, err := os.Stat(*file); err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to read the input:", err)
+			return 1
+		}
+		input = util.ReadInput(*file)
+	} else {
+		var err error
+		if input, err = util.LoadInput(*year, *day); err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to load the input:", err)
+			return 1
+		}
+	}
+
+	sol := solution.NewWithOutput(*day, *format, os.Stdout)
+	if err := sol.Solve(solver, input); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to solve:", err)
+		return 1
+	}
+	return 0
+}
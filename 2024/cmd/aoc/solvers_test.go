@@ -0,0 +1,67 @@
+package main
+
+import (
+	"2024/solution"
+	"2024/util"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestDays_File runs every day of the module the way aoc submit and aoc watch do, with -file pointing at the day's
+// first example, and checks the answers come back as JSON on stdout
+func TestDays_File(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs every day")
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	module, err := util.ModulePath(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	year, err := strconv.Atoi(module)
+	if err != nil {
+		t.Skipf("the module %q isn't named after a year", module)
+	}
+
+	bin := t.TempDir()
+	packages := make([]string, 0)
+	for day := 1; day <= 25; day++ {
+		if _, err := os.Stat(filepath.Join(root, dayDir(module, year, day), "testdata", "example1.txt")); err == nil {
+			packages = append(packages, "./"+dayDir(module, year, day))
+		}
+	}
+	build := exec.Command("go", append([]string{"build", "-o", bin + string(filepath.Separator)}, packages...)...)
+	build.Dir = root
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("Unable to build the days: %v\n%s", err, output)
+	}
+
+	for _, pkg := range packages {
+		name := filepath.Base(pkg)
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			var stdout, stderr bytes.Buffer
+			cmd := exec.CommandContext(ctx, filepath.Join(bin, name), "-format", solution.JSON, "-quiet", "-file", filepath.Join(root, pkg, "testdata", "example1.txt"))
+			cmd.Dir, cmd.Stdout, cmd.Stderr = root, &stdout, &stderr
+			if err := cmd.Run(); err != nil {
+				t.Fatalf("%v\n%s", err, stderr.String())
+			}
+			results, err := solution.Parse(&stdout)
+			if err != nil || len(results) == 0 {
+				t.Fatalf("Expected the answers as JSON, got %v\n%s", err, stdout.String())
+			}
+		})
+	}
+}
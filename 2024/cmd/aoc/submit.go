@@ -0,0 +1,142 @@
+package main
+
+import (
+	"2024/solution"
+	"2024/util"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+// runSubmit posts the answer to a part, checking it against the history of the day first so known wrong answers
+// are never sent again. Exits with 0 only when the answer was right
+func runSubmit(args []string) int {
+	flags := flag.NewFlagSet("submit", flag.ExitOnError)
+	year := flags.Int("year", util.ModuleYear(), "Year of the puzzle")
+	day := flags.Int("day", 0, "Day of the puzzle, 1 to 25")
+	part := flags.Int("part", 0, "Part to submit, 1 or 2")
+	answer := flags.String("answer", "", "Answer to submit instead of solving the day")
+	results := flags.String("results", "", "File with the solver's JSON results to take the answer from, - for stdin")
+	flags.Parse(args)
+
+	if *year == 0 || *day < 1 || *day > 25 || (*part != 1 && *part != 2) {
+		fmt.Fprintln(os.Stderr, "A -year, a -day from 1 to 25 and a -part of 1 or 2 are needed")
+		return 2
+	}
+
+	fetcher, err := util.NewFetcher()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to set up the client:", err)
+		return 1
+	}
+
+	history, err := util.LoadHistory(fetcher.HistoryPath(*year, *day))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to read the answer history:", err)
+		return 1
+	}
+	if solved, ok := history.Solved(*part); ok {
+		fmt.Printf("Day %d part %d is already solved, the answer was %s\n", *day, *part, solved)
+		return 0
+	}
+
+	if *answer == "" {
+		solved, err := solveForSubmit(fetcher, *year, *day, *results)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to get the answer, pass it with -answer instead:", err)
+			return 1
+		}
+		if *answer, err = answerFor(solved, *part); err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to get the answer:", err)
+			return 1
+		}
+	}
+
+	if err := history.Check(*part, *answer); err != nil {
+		fmt.Fprintln(os.Stderr, "Not submitting:", err)
+		return 1
+	}
+
+	submission, err := fetcher.Submit(*year, *day, *part, *answer)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to submit:", err)
+		return 1
+	}
+	history.Record(*part, *answer, submission.Verdict)
+	if err := history.Save(); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to save the answer history:", err)
+	}
+
+	fmt.Printf("Day %d part %d: %s is %s\n", *day, *part, *answer, submission.Verdict)
+	switch {
+	case submission.Verdict == util.RateLimited && submission.Wait > 0:
+		fmt.Printf("Try again in %s\n", submission.Wait)
+	case submission.Verdict.IsWrong():
+		fmt.Printf("The answer is %s\n", history.Bounds(*part))
+	case submission.Verdict == util.WrongLevel || submission.Verdict == util.Unknown:
+		fmt.Println(submission.Message)
+	}
+	if submission.Verdict != util.Correct {
+		return 1
+	}
+	return 0
+}
+
+// solveForSubmit gets the results of a day, from a results file when there is one, otherwise by solving it with the
+// registered solver or by running the day's main package for days of the module's own year
+func solveForSubmit(fetcher *util.Fetcher, year, day int, resultsPath string) ([]solution.Result, error) {
+	switch resultsPath {
+	case "":
+	case "-":
+		return solution.Parse(os.Stdin)
+	default:
+		file, err := os.Open(resultsPath)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return solution.Parse(file)
+	}
+
+	input, err := fetcher.Input(year, day)
+	if err != nil {
+		return nil, err
+	}
+
+	if solver, ok := solution.Lookup(year, day); ok {
+		sol := solution.NewWithOutput(day, solution.JSON, io.Discard)
+		if err := sol.Solve(solver, input); err != nil {
+			return nil, err
+		}
+		return sol.Results(), nil
+	}
+
+	if year != util.ModuleYear() {
+		return nil, fmt.Errorf("day %d of %d isn't registered", day, year)
+	}
+	var output bytes.Buffer
+	cmd := exec.Command("go", "run", fmt.Sprintf("./Day%d", day), "-format", solution.JSON, "-quiet", "-file", fetcher.Path(year, day))
+	cmd.Stdout = &output
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("day %d failed: %w", day, err)
+	}
+	return solution.Parse(&output)
+}
+
+// answerFor picks the answer to a part out of the results
+func answerFor(results []solution.Result, part int) (string, error) {
+	for _, result := range results {
+		if result.Part != part {
+			continue
+		}
+		if result.Answer == "" {
+			return "", fmt.Errorf("part %d has no answer", part)
+		}
+		return result.Answer, nil
+	}
+	return "", fmt.Errorf("no result for part %d", part)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	computer "2024/Day17/threebitcomputer"
+	"2024/util"
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// runVM loads a Day 17 program into the 3-bit computer debugger and reads its commands from stdin until quit.
+// An empty line repeats the last command, so stepping is a matter of pressing enter. With -record it runs the program
+// to the end and saves its trace instead, which -replay opens again to step through in either direction
+func runVM(args []string) int {
+	flags := flag.NewFlagSet("vm", flag.ExitOnError)
+	file := flags.String("file", "", "Day 17 input to load, by default the saved input of day 17, downloaded if it isn't saved yet")
+	record := flags.String("record", "", "run the program to the end and write its trace to this file as JSON Lines")
+	replay := flags.String("replay", "", "step through a trace written by -record or export instead of running a program")
+	limit := flags.Int("limit", 1_000_000, "most instructions -record runs before giving up on the program halting")
+	flags.Parse(args)
+
+	if *replay != "" {
+		return replayTrace(*replay)
+	}
+
+	var input []string
+	if *file != "" {
+		if _, err := os.Stat(*file); err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to read the input:", err)
+			return 1
+		}
+		input = util.ReadInput(*file)
+	} else {
+		var err error
+		if input, err = util.LoadInput(util.ModuleYear(), 17); err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to load the input:", err)
+			return 1
+		}
+	}
+
+	a, b, c, program, err := computer.ParseInput(input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to read the program:", err)
+		return 1
+	}
+	if *record != "" {
+		return recordTrace(*record, computer.NewComputer(a, b, c, false), program, *limit)
+	}
+	debugger := computer.NewDebugger(a, b, c, program, os.Stdout)
+	fmt.Printf("Loaded %d instructions with A=%d B=%d C=%d, type help for the commands\n", len(program)/2, a, b, c)
+	debugger.Exec("disasm")
+	repl(debugger, os.Stdin, os.Stdout)
+	return 0
+}
+
+// recordTrace runs the program to the end and writes what it did to file. A program that doesn't halt within limit
+// instructions still has the instructions it ran written, for replay to look into why
+func recordTrace(file string, comp *computer.Computer, program []int, limit int) int {
+	trace, runErr := computer.Record(comp, program, limit)
+	out, err := os.Create(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to write the trace:", err)
+		return 1
+	}
+	defer out.Close()
+	if err := trace.WriteJSONL(out); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to write the trace:", err)
+		return 1
+	}
+	if runErr != nil {
+		fmt.Fprintln(os.Stderr, "Unable to run the program:", runErr)
+		return 1
+	}
+	fmt.Printf("Wrote %d instructions to %s, output %s\n", len(trace.Events), file, strings.Join(comp.GetOutput(), ","))
+	return 0
+}
+
+// replayTrace opens a recorded trace in the viewer
+func replayTrace(file string) int {
+	in, err := os.Open(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to read the trace:", err)
+		return 1
+	}
+	trace, err := computer.ReadTrace(in)
+	in.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to read the trace:", err)
+		return 1
+	}
+
+	viewer := computer.NewViewer(trace, os.Stdout)
+	fmt.Printf("Loaded a trace of %d instructions, type help for the commands\n", len(trace.Events))
+	viewer.Exec("regs")
+	repl(viewer, os.Stdin, os.Stdout)
+	return 0
+}
+
+// session is what repl feeds lines to, the debugger or the trace viewer
+type session interface {
+	Exec(line string) (bool, error)
+}
+
+// repl feeds the session one line at a time, printing a prompt before each
+func repl(debugger session, in io.Reader, out io.Writer) {
+	scanner := bufio.NewScanner(in)
+	last := ""
+	for {
+		fmt.Fprint(out, "(vm) ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return
+		}
+		line := scanner.Text()
+		if line == "" {
+			line = last
+		}
+		last = line
+
+		quit, err := debugger.Exec(line)
+		if err != nil {
+			fmt.Fprintln(out, err)
+		}
+		if quit {
+			return
+		}
+	}
+}
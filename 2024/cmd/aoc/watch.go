@@ -0,0 +1,192 @@
+package main
+
+import (
+	"2024/solution"
+	"2024/util"
+	"2024/watch"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// runWatch reruns the tests of a day and then its solver on the real input every time the package or the input
+// changes, and prints how the answers and timings moved since the run before
+func runWatch(args []string) int {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	year := flags.Int("year", util.ModuleYear(), "Year of the puzzle")
+	day := flags.Int("day", 0, "Day of the puzzle, 1 to 25")
+	file := flags.String("file", "", "Puzzle input, by default the saved input of the day, downloaded if it isn't saved yet")
+	poll := flags.Bool("poll", false, "Poll for changes instead of using inotify")
+	interval := flags.Duration("interval", 500*time.Millisecond, "How often to poll, also used where inotify isn't available")
+	flags.Parse(args)
+
+	if *year == 0 || *day < 1 || *day > 25 {
+		fmt.Fprintln(os.Stderr, "A -year and a -day from 1 to 25 are needed")
+		return 2
+	}
+
+	module, err := util.ModulePath(".")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to read the module path, aoc has to run from the module root:", err)
+		return 1
+	}
+	dir := dayDir(module, *year, *day)
+	if _, err := os.Stat(dir); err != nil {
+		fmt.Fprintf(os.Stderr, "No package for day %d at %s\n", *day, dir)
+		return 1
+	}
+
+	input := *file
+	if input == "" {
+		fetcher, err := util.NewFetcher()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to set up the fetcher:", err)
+			return 1
+		}
+		if _, err := fetcher.Input(*year, *day); err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to load the input:", err)
+			return 1
+		}
+		input = fetcher.Path(*year, *day)
+	}
+
+	binDir, err := os.MkdirTemp("", "aoc-watch")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to make a directory for the solver:", err)
+		return 1
+	}
+	defer os.RemoveAll(binDir)
+	target := newWatchedDay(module, *year, *day, dir, input, filepath.Join(binDir, "solver"))
+
+	var watcher *watch.Watcher
+	if *poll {
+		watcher, err = watch.NewPolling([]string{dir, input}, *interval)
+	} else {
+		watcher, err = watch.New([]string{dir, input}, *interval)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to watch the day:", err)
+		return 1
+	}
+	defer watcher.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Watching %s and %s with %s, Ctrl-C to stop\n", dir, input, watcher.Backend)
+	previous := target.run(ctx, nil)
+	for {
+		select {
+		case <-ctx.Done():
+			return 0
+		case changed, ok := <-watcher.Changes():
+			if !ok {
+				return 0
+			}
+			fmt.Printf("\n%s changed\n", strings.Join(changed, ", "))
+			previous = target.run(ctx, previous)
+		}
+	}
+}
+
+// watchedDay is how a watched day is tested and solved
+//   - test: package the tests run in
+//   - build: package built into the solver, the day itself or aoc for days registered with the runner
+//   - binary: where the solver is built to
+//   - args: arguments that make the solver read the real input and print its results as JSON
+type watchedDay struct {
+	test   string
+	build  string
+	binary string
+	args   []string
+}
+
+// newWatchedDay runs the main package of a day of the module's own year, days of other years through aoc run
+func newWatchedDay(module string, year, day int, dir, input, binary string) watchedDay {
+	pkg := "./" + filepath.ToSlash(dir)
+	if module == strconv.Itoa(year) {
+		return watchedDay{test: pkg, build: pkg, binary: binary, args: []string{"-format", solution.JSON, "-quiet", "-file", input}}
+	}
+	args := []string{"run", "-year", strconv.Itoa(year), "-day", strconv.Itoa(day), "-format", solution.JSON, "-file", input}
+	return watchedDay{test: pkg, build: "./cmd/aoc", binary: binary, args: args}
+}
+
+// run tests the day, then builds and solves it and prints the results next to the previous ones. The real input
+// is only run once the tests pass, the previous results are kept when anything fails so the next diff is against
+// the last run that worked
+func (w watchedDay) run(ctx context.Context, previous []solution.Result) []solution.Result {
+	fmt.Printf("Testing %s\n", w.test)
+	if err := runProgram(ctx, os.Stdout, "go", "test", w.test); err != nil {
+		fmt.Println("Tests failed, the real input runs once they pass")
+		return previous
+	}
+	if err := runProgram(ctx, os.Stdout, "go", "build", "-o", w.binary, w.build); err != nil {
+		fmt.Println("Build failed:", err)
+		return previous
+	}
+
+	var output bytes.Buffer
+	if err := runProgram(ctx, &output, w.binary, w.args...); err != nil {
+		fmt.Println("The solver failed on the real input:", err)
+		return previous
+	}
+	results, err := solution.Parse(&output)
+	if err != nil {
+		fmt.Println("Unable to read the solver's results:", err)
+		return previous
+	}
+	printChanges(os.Stdout, previous, results)
+	return results
+}
+
+// runProgram runs a program with its output going to stdout and its errors to stderr
+func runProgram(ctx context.Context, stdout io.Writer, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// printChanges writes the answer and time of every part, next to the ones from the previous run when there is one
+func printChanges(out io.Writer, previous, current []solution.Result) {
+	before := make(map[int]solution.Result, len(previous))
+	for _, result := range previous {
+		before[result.Part] = result
+	}
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "Part\tAnswer\tTime\t")
+	for _, result := range current {
+		answer := result.Answer
+		if answer == "" {
+			answer = "(no answer)"
+		}
+		elapsed := result.Elapsed.Round(time.Microsecond).String()
+
+		if old, ok := before[result.Part]; ok {
+			if old.Answer == result.Answer {
+				answer += " (same)"
+			} else if old.Answer == "" {
+				answer += " (was unanswered)"
+			} else {
+				answer += fmt.Sprintf(" (was %s)", old.Answer)
+			}
+			if old.Elapsed > 0 {
+				delta := (float64(result.Elapsed)/float64(old.Elapsed) - 1) * 100
+				elapsed += fmt.Sprintf(" (was %s, %+.1f%%)", old.Elapsed.Round(time.Microsecond), delta)
+			}
+		}
+		fmt.Fprintf(table, "%d\t%s\t%s\t\n", result.Part, answer, elapsed)
+	}
+	table.Flush()
+}
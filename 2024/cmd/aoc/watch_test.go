@@ -0,0 +1,53 @@
+package main
+
+import (
+	"2024/solution"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintChanges(t *testing.T) {
+	previous := []solution.Result{
+		{Day: 5, Part: 1, Answer: "143", Elapsed: 2 * time.Millisecond},
+		{Day: 5, Part: 2, Answer: "", Elapsed: time.Microsecond},
+	}
+	current := []solution.Result{
+		{Day: 5, Part: 1, Answer: "143", Elapsed: time.Millisecond},
+		{Day: 5, Part: 2, Answer: "123", Elapsed: 3 * time.Millisecond},
+	}
+
+	var out strings.Builder
+	printChanges(&out, nil, previous)
+	first := out.String()
+	if !strings.Contains(first, "143") || strings.Contains(first, "was") {
+		t.Errorf("Expected the first run to have nothing to compare with, got\n%s", first)
+	}
+
+	out.Reset()
+	printChanges(&out, previous, current)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected a header and a line per part, got\n%s", out.String())
+	}
+	for _, want := range []string{"143 (same)", "1ms (was 2ms, -50.0%)"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("Expected %q in %q", want, lines[1])
+		}
+	}
+	if !strings.Contains(lines[2], "123 (was unanswered)") {
+		t.Errorf("Expected part 2 to show it has an answer now, got %q", lines[2])
+	}
+}
+
+func TestNewWatchedDay(t *testing.T) {
+	own := newWatchedDay("2024", 2024, 5, "Day5", "inputs/day5.txt", "solver")
+	if own.test != "./Day5" || own.build != "./Day5" || own.args[len(own.args)-1] != "inputs/day5.txt" {
+		t.Errorf("Expected the day's own main package to be run, got %+v", own)
+	}
+
+	other := newWatchedDay("2024", 2025, 1, "2025/Day1", "inputs/2025/day1.txt", "solver")
+	if other.test != "./2025/Day1" || other.build != "./cmd/aoc" || other.args[0] != "run" {
+		t.Errorf("Expected other years to be run through aoc run, got %+v", other)
+	}
+}
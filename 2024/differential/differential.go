@@ -0,0 +1,159 @@
+// Package differential checks an optimized solver against a slow reference that tries every possibility. Both run
+// on thousands of generated cases, and when they disagree the case is shrunk to the smallest one that still shows
+// the difference, so the failure is something a person can work through by hand
+package differential
+
+import (
+	"2024/gen"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+const (
+	// CasesEnv overrides the number of cases every test runs
+	CasesEnv = "AOC_DIFFERENTIAL_CASES"
+	// DefaultCases is the number of cases a test runs when neither the test nor CasesEnv says otherwise
+	DefaultCases = 1000
+	// maxShrinks stops shrinking a case that keeps getting smaller without end
+	maxShrinks = 10000
+)
+
+// Test pits two solvers for the same question against each other
+//   - Generate: makes a case from the randomness it is given
+//   - Shrink: the cases one step smaller than a case, nil when it can't get smaller. Optional
+//   - Reference: the exhaustive solver, slow but obviously right
+//   - Optimized: the solver being checked
+//   - Cases: how many cases to run, 0 for the default
+type Test[T any] struct {
+	Generate  func(r *rand.Rand) T
+	Shrink    func(c T) []T
+	Reference func(c T) any
+	Optimized func(c T) any
+	Cases     int
+}
+
+// Mismatch is a case the two solvers disagree on
+//   - Seed: the seed the original case was generated from
+//   - Original: the case as it was generated
+//   - Case: the smallest case found from it that still disagrees
+//   - Reference, Optimized: the answers for Case, a panic is reported as "panic: " and its value
+//   - Shrinks: the number of shrinking steps from Original to Case
+type Mismatch[T any] struct {
+	Seed      uint64
+	Original  T
+	Case      T
+	Reference any
+	Optimized any
+	Shrinks   int
+}
+
+// String describes the mismatch in a way that can be pasted into a test
+func (m *Mismatch[T]) String() string {
+	return fmt.Sprintf("seed %d shrunk in %d steps to %+v: reference says %v, optimized says %v",
+		m.Seed, m.Shrinks, m.Case, m.Reference, m.Optimized)
+}
+
+// Find runs the cases generated from the seeds first, first+1 and so on, and returns the first mismatch shrunk as far
+// as it goes. Returns nil if the solvers agree on every case
+func (t Test[T]) Find(first uint64) *Mismatch[T] {
+	for seed := first; seed < first+uint64(t.cases()); seed++ {
+		c := t.Generate(gen.New(seed))
+		reference, optimized, same := t.compare(c)
+		if same {
+			continue
+		}
+
+		mismatch := &Mismatch[T]{Seed: seed, Original: c, Case: c, Reference: reference, Optimized: optimized}
+		t.shrink(mismatch)
+		return mismatch
+	}
+	return nil
+}
+
+// Run fails the test with the shrunk case if the solvers disagree on any case
+func Run[T any](tb testing.TB, test Test[T]) {
+	tb.Helper()
+	if mismatch := test.Find(0); mismatch != nil {
+		tb.Fatal(mismatch)
+	}
+}
+
+// shrink replaces the case of the mismatch with the first smaller case that still disagrees, until none of the
+// smaller cases do
+func (t Test[T]) shrink(m *Mismatch[T]) {
+	if t.Shrink == nil {
+		return
+	}
+	for m.Shrinks < maxShrinks {
+		shrunk := false
+		for _, smaller := range t.Shrink(m.Case) {
+			reference, optimized, same := t.compare(smaller)
+			if same {
+				continue
+			}
+			m.Case, m.Reference, m.Optimized = smaller, reference, optimized
+			m.Shrinks++
+			shrunk = true
+			break
+		}
+		if !shrunk {
+			return
+		}
+	}
+}
+
+// compare runs both solvers on a case
+func (t Test[T]) compare(c T) (reference any, optimized any, same bool) {
+	reference = answer(t.Reference, c)
+	optimized = answer(t.Optimized, c)
+	return reference, optimized, reflect.DeepEqual(reference, optimized)
+}
+
+// cases is the number of cases to run, CasesEnv wins over the test so a long run can be asked for without editing it
+func (t Test[T]) cases() int {
+	if cases, err := strconv.Atoi(os.Getenv(CasesEnv)); err == nil && cases > 0 {
+		return cases
+	}
+	if t.Cases > 0 {
+		return t.Cases
+	}
+	return DefaultCases
+}
+
+// answer runs a solver, turning a panic into an answer so it shows up as a mismatch instead of stopping the run
+func answer[T any](solver func(T) any, c T) (toReturn any) {
+	defer func() {
+		if r := recover(); r != nil {
+			toReturn = fmt.Sprintf("panic: %v", r)
+		}
+	}()
+	return solver(c)
+}
+
+// Smaller returns the values one step smaller than n and at least low: low itself, half way there and one less.
+// It is the building block for shrinking cases made of numbers
+func Smaller(n, low int) []int {
+	toReturn := make([]int, 0, 3)
+	for _, candidate := range []int{low, low + (n-low)/2, n - 1} {
+		if candidate >= low && candidate < n && (len(toReturn) == 0 || toReturn[len(toReturn)-1] != candidate) {
+			toReturn = append(toReturn, candidate)
+		}
+	}
+	return toReturn
+}
+
+// Without returns every copy of values with one element removed
+func Without[E any](values []E) [][]E {
+	toReturn := make([][]E, 0, len(values))
+	for i := range values {
+		smaller := make([]E, 0, len(values)-1)
+		smaller = append(smaller, values[:i]...)
+		smaller = append(smaller, values[i+1:]...)
+		toReturn = append(toReturn, smaller)
+	}
+	return toReturn
+}
@@ -0,0 +1,115 @@
+package differential
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// sumTest adds up lists of numbers, the optimized side forgets numbers above 5
+func sumTest() Test[[]int] {
+	return Test[[]int]{
+		Generate: func(r *rand.Rand) []int {
+			values := make([]int, r.IntN(10))
+			for i := range values {
+				values[i] = r.IntN(20)
+			}
+			return values
+		},
+		Shrink: func(values []int) [][]int {
+			toReturn := Without(values)
+			for i, value := range values {
+				for _, smaller := range Smaller(value, 0) {
+					candidate := slices.Clone(values)
+					candidate[i] = smaller
+					toReturn = append(toReturn, candidate)
+				}
+			}
+			return toReturn
+		},
+		Reference: func(values []int) any {
+			sum := 0
+			for _, value := range values {
+				sum += value
+			}
+			return sum
+		},
+		Optimized: func(values []int) any {
+			sum := 0
+			for _, value := range values {
+				if value <= 5 {
+					sum += value
+				}
+			}
+			return sum
+		},
+		Cases: 100,
+	}
+}
+
+func TestFind_Shrinks(t *testing.T) {
+	mismatch := sumTest().Find(0)
+	if mismatch == nil {
+		t.Fatalf("Expected a mismatch")
+	}
+	if !slices.Equal(mismatch.Case, []int{6}) {
+		t.Errorf("Expected the case to shrink to [6], got %v from %v", mismatch.Case, mismatch.Original)
+	}
+	if mismatch.Reference != 6 || mismatch.Optimized != 0 {
+		t.Errorf("Expected the answers for the shrunk case, got %v and %v", mismatch.Reference, mismatch.Optimized)
+	}
+	if mismatch.Shrinks == 0 && len(mismatch.Original) > 1 {
+		t.Errorf("Expected shrinking steps to be counted")
+	}
+}
+
+func TestFind_Agree(t *testing.T) {
+	test := sumTest()
+	test.Optimized = test.Reference
+	if mismatch := test.Find(0); mismatch != nil {
+		t.Errorf("Expected no mismatch, got %v", mismatch)
+	}
+}
+
+func TestFind_Panic(t *testing.T) {
+	test := sumTest()
+	test.Optimized = func(values []int) any { return values[len(values)-1] * 0 }
+	test.Reference = func(values []int) any { return 0 }
+	mismatch := test.Find(0)
+	if mismatch == nil || len(mismatch.Case) != 0 || mismatch.Optimized == nil {
+		t.Fatalf("Expected the panic on an empty list to be a mismatch, got %v", mismatch)
+	}
+}
+
+func TestCases(t *testing.T) {
+	runs := 0
+	test := Test[int]{
+		Generate:  func(r *rand.Rand) int { runs++; return 0 },
+		Reference: func(int) any { return 0 },
+		Optimized: func(int) any { return 0 },
+	}
+	test.Find(0)
+	if runs != DefaultCases {
+		t.Errorf("Expected %d cases by default, got %d", DefaultCases, runs)
+	}
+
+	t.Setenv(CasesEnv, "7")
+	runs = 0
+	test.Cases = 3
+	test.Find(0)
+	if runs != 7 {
+		t.Errorf("Expected %s to win over the test, got %d cases", CasesEnv, runs)
+	}
+}
+
+func TestSmaller(t *testing.T) {
+	if got := Smaller(10, 0); !slices.Equal(got, []int{0, 5, 9}) {
+		t.Errorf("Expected [0 5 9], got %v", got)
+	}
+	if got := Smaller(2, 1); !slices.Equal(got, []int{1}) {
+		t.Errorf("Expected [1], got %v", got)
+	}
+	if got := Smaller(1, 1); len(got) != 0 {
+		t.Errorf("Expected nothing below the low end, got %v", got)
+	}
+}
@@ -0,0 +1,46 @@
+// Package fuzztest holds the helpers the days' fuzz targets share: seeding the corpus with the day's examples and
+// splitting a fuzzed input into lines the way the solvers read their input files
+package fuzztest
+
+import (
+	"2024/util"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Corpus seeds a fuzz target with the example inputs of the day, the exampleN.txt files in the testdata directory of
+// the package that aoc examples writes. Skips the fuzz target if there are none
+func Corpus(f *testing.F) {
+	f.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", "example*.txt"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	if len(paths) == 0 {
+		f.Skip("No examples in testdata, run aoc examples first")
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatalf("Unable to read example %s: %v", path, err)
+		}
+		f.Add(string(data))
+	}
+}
+
+// FuzzLines seeds the fuzz target with the day's examples and hands fuzz every mutated input split into lines.
+// Parsers are expected to reject input they can't handle, fuzz checks what holds for everything they accept
+func FuzzLines(f *testing.F, fuzz func(t *testing.T, lines []string)) {
+	f.Helper()
+	Corpus(f)
+	f.Fuzz(func(t *testing.T, data string) {
+		fuzz(t, Lines(data))
+	})
+}
+
+// Lines splits a fuzzed input into lines the way the solvers read their input files
+func Lines(data string) []string {
+	return util.ReadLines(strings.NewReader(data))
+}
@@ -0,0 +1,12 @@
+package fuzztest
+
+import (
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	lines := Lines("029A\r\n980A\n\n179A")
+	if len(lines) != 4 || lines[0] != "029A" || lines[2] != "" || lines[3] != "179A" {
+		t.Errorf("Unexpected lines %q", lines)
+	}
+}
@@ -0,0 +1,127 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// Adder is a Day 24 ripple-carry adder
+//   - Lines: the input, initial wire values then the gates
+//   - X, Y: the numbers on the x and y wires
+//   - Swaps: the output wires of the gates that were swapped, sorted
+type Adder struct {
+	Lines []string
+	X, Y  int
+	Swaps []string
+}
+
+// adderGate is a gate of the adder before it is written out
+type adderGate struct {
+	one, two, operation, output string
+}
+
+// NewAdder builds a ripple-carry adder for two numbers of the given number of bits out of full adders, then swaps the
+// outputs of swaps pairs of gates. The swaps are the kinds the real inputs have, each inside the full adder of a
+// different bit so the circuit never loops: the x XOR y gate with the x AND y gate, or the gate that should
+// output z with another gate of the same bit. An adder needs from 2 to 62 bits, and neither the lowest bit nor the
+// top one is swapped, so there can be at most bits-2 swaps
+func NewAdder(r *rand.Rand, bits int, swaps int) (Adder, error) {
+	if bits < 2 || bits > 62 {
+		return Adder{}, fmt.Errorf("an adder of %d bits can't be built, it needs from 2 to 62", bits)
+	}
+	if swaps < 0 || swaps > bits-2 {
+		return Adder{}, fmt.Errorf("%d swaps don't fit in an adder of %d bits, it takes from 0 to %d", swaps, bits, bits-2)
+	}
+
+	names := newNames(r)
+	gates := make([]adderGate, 0, 5*bits)
+	// bitGates holds the index in gates of xor, and, z, carry-and and carry-or for every bit
+	bitGates := make([][5]int, bits)
+
+	add := func(one, two, operation, output string) int {
+		gates = append(gates, adderGate{one, two, operation, output})
+		return len(gates) - 1
+	}
+	carry := ""
+	for bit := 0; bit < bits; bit++ {
+		x, y, z := fmt.Sprintf("x%02d", bit), fmt.Sprintf("y%02d", bit), fmt.Sprintf("z%02d", bit)
+		if bit == 0 {
+			bitGates[bit][2] = add(x, y, "XOR", z)
+			carry = names()
+			bitGates[bit][1] = add(x, y, "AND", carry)
+			continue
+		}
+
+		sum, direct, indirect := names(), names(), names()
+		next := names()
+		if bit == bits-1 {
+			next = fmt.Sprintf("z%02d", bits)
+		}
+		bitGates[bit] = [5]int{
+			add(x, y, "XOR", sum),
+			add(x, y, "AND", direct),
+			add(sum, carry, "XOR", z),
+			add(sum, carry, "AND", indirect),
+			add(direct, indirect, "OR", next),
+		}
+		carry = next
+	}
+
+	// the last bit's carry is the top z wire, so swaps stay below it
+	swapped := make([]string, 0, 2*swaps)
+	for _, bit := range r.Perm(bits - 2)[:swaps] {
+		indices := bitGates[bit+1]
+		a, b := indices[0], indices[1]
+		if r.IntN(2) == 0 {
+			a, b = indices[2], indices[1+r.IntN(4)]
+			if b == indices[2] {
+				b = indices[4]
+			}
+		}
+		gates[a].output, gates[b].output = gates[b].output, gates[a].output
+		swapped = append(swapped, gates[a].output, gates[b].output)
+	}
+	slices.Sort(swapped)
+
+	adder := Adder{X: r.IntN(1 << bits), Y: r.IntN(1 << bits), Swaps: swapped}
+	adder.Lines = make([]string, 0, 2*bits+1+len(gates))
+	for _, wire := range []string{"x", "y"} {
+		value := adder.X
+		if wire == "y" {
+			value = adder.Y
+		}
+		for bit := 0; bit < bits; bit++ {
+			adder.Lines = append(adder.Lines, fmt.Sprintf("%s%02d: %d", wire, bit, value>>bit&1))
+		}
+	}
+	adder.Lines = append(adder.Lines, "")
+
+	r.Shuffle(len(gates), func(i, j int) { gates[i], gates[j] = gates[j], gates[i] })
+	for _, g := range gates {
+		one, two := g.one, g.two
+		if r.IntN(2) == 0 {
+			one, two = two, one
+		}
+		adder.Lines = append(adder.Lines, fmt.Sprintf("%s %s %s -> %s", one, g.operation, two, g.output))
+	}
+	return adder, nil
+}
+
+// newNames hands out random three letter wire names that can't be confused with the x, y and z wires
+func newNames(r *rand.Rand) func() string {
+	used := make(map[string]bool)
+	return func() string {
+		for {
+			var name strings.Builder
+			name.WriteByte(byte('a' + r.IntN(23)))
+			name.WriteByte(byte('a' + r.IntN(26)))
+			name.WriteByte(byte('a' + r.IntN(26)))
+			if !used[name.String()] {
+				used[name.String()] = true
+				return name.String()
+			}
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// ByteDrops is a Day 18 list of falling bytes
+//   - Lines: the input, one x,y per line
+//   - Bytes: the same coordinates in the order they fall
+type ByteDrops struct {
+	Lines []string
+	Bytes [][2]int
+}
+
+// NewByteDrops drops count bytes on distinct cells of a width x height memory space. The start in the top left and
+// the exit in the bottom right are never hit, so count can be at most width*height-2
+func NewByteDrops(r *rand.Rand, width, height, count int) ByteDrops {
+	cells := make([][2]int, 0, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if (x == 0 && y == 0) || (x == width-1 && y == height-1) {
+				continue
+			}
+			cells = append(cells, [2]int{x, y})
+		}
+	}
+	r.Shuffle(len(cells), func(i, j int) { cells[i], cells[j] = cells[j], cells[i] })
+
+	drops := ByteDrops{Lines: make([]string, count), Bytes: cells[:count]}
+	for i, b := range drops.Bytes {
+		drops.Lines[i] = fmt.Sprintf("%d,%d", b[0], b[1])
+	}
+	return drops
+}
@@ -0,0 +1,72 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// ClawMachines is a Day 13 list of claw machines
+//   - Lines: the input, one block of three lines per machine
+//   - Cost: the fewest tokens needed to win every prize that can be won, 3 per A press and 1 per B press
+//   - Winnable: how many of the prizes can be won
+type ClawMachines struct {
+	Lines    []string
+	Cost     int
+	Winnable int
+}
+
+// NewClawMachines makes count machines whose prizes sit offset further away than the input says, like part 2 moves
+// them by 10000000000000, and that can be pressed limit times per button, 0 for no limit. Buttons move 10 to 99 in
+// each direction and never along the same line, so a prize is won with exactly one number of presses. About half
+// of the prizes are made from presses and can be won. The rest are nudged off those so they usually can't, Cost
+// and Winnable count them when they still can
+func NewClawMachines(r *rand.Rand, count int, offset int, limit int) ClawMachines {
+	// without a limit the presses have to be big enough to reach past the offset
+	low, high := 0, limit
+	if limit == 0 {
+		low, high = offset/20, max(offset/5, 100)
+	}
+
+	machines := ClawMachines{Lines: make([]string, 0, 4*count)}
+	for m := 0; m < count; m++ {
+		var ax, ay, bx, by int
+		for ax*by == ay*bx {
+			ax, ay, bx, by = between(r, 10, 99), between(r, 10, 99), between(r, 10, 99), between(r, 10, 99)
+		}
+
+		var px, py int
+		for px <= offset || py <= offset {
+			a, b := between(r, low, high), between(r, low, high)
+			px, py = a*ax+b*bx, a*ay+b*by
+		}
+		if r.IntN(2) == 0 {
+			px += between(r, 1, 5)
+		}
+
+		if a, b, ok := Presses(ax, ay, bx, by, px, py); ok && (limit == 0 || (a <= limit && b <= limit)) {
+			machines.Cost += 3*a + b
+			machines.Winnable++
+		}
+
+		if m > 0 {
+			machines.Lines = append(machines.Lines, "")
+		}
+		machines.Lines = append(machines.Lines,
+			fmt.Sprintf("Button A: X+%d, Y+%d", ax, ay),
+			fmt.Sprintf("Button B: X+%d, Y+%d", bx, by),
+			fmt.Sprintf("Prize: X=%d, Y=%d", px-offset, py-offset))
+	}
+	return machines
+}
+
+// Presses solves a*A + b*B = P exactly with Cramer's rule, ok is false when there is no solution in non-negative
+// whole presses. The buttons must not move along the same line
+func Presses(ax, ay, bx, by, px, py int) (a, b int, ok bool) {
+	det := ax*by - ay*bx
+	aNum, bNum := px*by-py*bx, ax*py-ay*px
+	if aNum%det != 0 || bNum%det != 0 {
+		return 0, 0, false
+	}
+	a, b = aNum/det, bNum/det
+	return a, b, a >= 0 && b >= 0
+}
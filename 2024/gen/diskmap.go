@@ -0,0 +1,47 @@
+package gen
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+// DiskMap is a Day 9 disk map
+//   - Line: the dense format, file and free space sizes alternating, starting and ending with a file
+//   - Blocks: the map unpacked, the file ID of every block and -1 for free blocks
+type DiskMap struct {
+	Line   string
+	Blocks []int
+}
+
+// NewDiskMap makes a disk map with the given number of files. Files are 1 to 9 blocks long and the gaps between
+// them 0 to 9, like the real input
+func NewDiskMap(r *rand.Rand, files int) DiskMap {
+	var line strings.Builder
+	blocks := make([]int, 0)
+	for id := 0; id < files; id++ {
+		if id > 0 {
+			free := r.IntN(10)
+			line.WriteByte(byte('0' + free))
+			for i := 0; i < free; i++ {
+				blocks = append(blocks, -1)
+			}
+		}
+		size := between(r, 1, 9)
+		line.WriteByte(byte('0' + size))
+		for i := 0; i < size; i++ {
+			blocks = append(blocks, id)
+		}
+	}
+	return DiskMap{Line: line.String(), Blocks: blocks}
+}
+
+// Checksum adds up position times file ID over the blocks, skipping free blocks
+func Checksum(blocks []int) int {
+	sum := 0
+	for position, id := range blocks {
+		if id >= 0 {
+			sum += position * id
+		}
+	}
+	return sum
+}
@@ -0,0 +1,18 @@
+// Package gen makes random puzzle inputs that are valid for their day, along with facts about them that the
+// solvers have to agree with. Generators take the *rand.Rand to draw from, so a failing property can be replayed
+// from its seed
+package gen
+
+import (
+	"math/rand/v2"
+)
+
+// New returns a source of randomness for a seed
+func New(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+}
+
+// between returns a random int in [low, high]
+func between(r *rand.Rand, low, high int) int {
+	return low + r.IntN(high-low+1)
+}
@@ -0,0 +1,194 @@
+package gen
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNewDiskMap(t *testing.T) {
+	for seed := uint64(0); seed < 50; seed++ {
+		disk := NewDiskMap(New(seed), 20)
+		if len(disk.Line) != 39 {
+			t.Fatalf("Seed %d: expected 39 digits for 20 files, got %d", seed, len(disk.Line))
+		}
+
+		blocks := make([]int, 0)
+		for i, digit := range disk.Line {
+			id := -1
+			if i%2 == 0 {
+				id = i / 2
+			}
+			for n := 0; n < int(digit-'0'); n++ {
+				blocks = append(blocks, id)
+			}
+		}
+		if !slices.Equal(blocks, disk.Blocks) {
+			t.Fatalf("Seed %d: blocks don't match %s", seed, disk.Line)
+		}
+	}
+
+	if sum := Checksum([]int{0, 0, 9, 9, -1, 1}); sum != 0+0+18+27+5 {
+		t.Errorf("Unexpected checksum %d", sum)
+	}
+}
+
+// evaluate runs the gates of an adder and returns the number on the z wires
+func evaluate(t *testing.T, lines []string) int {
+	t.Helper()
+	wires := make(map[string]int)
+	split := slices.Index(lines, "")
+	for _, line := range lines[:split] {
+		name, value, _ := strings.Cut(line, ": ")
+		wires[name], _ = strconv.Atoi(value)
+	}
+
+	pending := lines[split+1:]
+	for len(pending) > 0 {
+		waiting := make([]string, 0)
+		for _, line := range pending {
+			var one, operation, two, output string
+			fmt.Sscanf(line, "%s %s %s -> %s", &one, &operation, &two, &output)
+			a, okA := wires[one]
+			b, okB := wires[two]
+			if !okA || !okB {
+				waiting = append(waiting, line)
+				continue
+			}
+			switch operation {
+			case "AND":
+				wires[output] = a & b
+			case "OR":
+				wires[output] = a | b
+			case "XOR":
+				wires[output] = a ^ b
+			}
+		}
+		if len(waiting) == len(pending) {
+			t.Fatalf("The gates loop: %v", waiting)
+		}
+		pending = waiting
+	}
+
+	z := 0
+	for name, value := range wires {
+		if name[0] == 'z' {
+			bit, _ := strconv.Atoi(name[1:])
+			z |= value << bit
+		}
+	}
+	return z
+}
+
+func TestNewAdder(t *testing.T) {
+	for seed := uint64(0); seed < 50; seed++ {
+		adder, err := NewAdder(New(seed), 12, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if z := evaluate(t, adder.Lines); z != adder.X+adder.Y {
+			t.Fatalf("Seed %d: %d + %d gave %d", seed, adder.X, adder.Y, z)
+		}
+
+		swapped, err := NewAdder(New(seed), 12, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(swapped.Swaps) != 8 || len(slices.Compact(slices.Clone(swapped.Swaps))) != 8 {
+			t.Fatalf("Seed %d: expected 8 distinct swapped wires, got %v", seed, swapped.Swaps)
+		}
+		// swaps never make the circuit loop
+		evaluate(t, swapped.Lines)
+	}
+}
+
+func TestNewAdder_Invalid(t *testing.T) {
+	for _, c := range [][2]int{{1, 0}, {0, 0}, {63, 0}, {12, 11}, {2, 1}, {12, -1}} {
+		if _, err := NewAdder(New(1), c[0], c[1]); err == nil {
+			t.Errorf("Expected an error for %d bits with %d swaps", c[0], c[1])
+		}
+	}
+	if _, err := NewAdder(New(1), 12, 10); err != nil {
+		t.Errorf("Expected a swap in every bit but the lowest and the top one to fit, got %v", err)
+	}
+}
+
+func TestNewByteDrops(t *testing.T) {
+	drops := NewByteDrops(New(1), 7, 7, 47)
+	seen := make(map[[2]int]bool)
+	for i, b := range drops.Bytes {
+		if b[0] < 0 || b[0] >= 7 || b[1] < 0 || b[1] >= 7 || b == [2]int{0, 0} || b == [2]int{6, 6} || seen[b] {
+			t.Fatalf("Invalid byte %v", b)
+		}
+		seen[b] = true
+		if drops.Lines[i] != fmt.Sprintf("%d,%d", b[0], b[1]) {
+			t.Fatalf("Line %q doesn't match %v", drops.Lines[i], b)
+		}
+	}
+}
+
+func TestNewPrintQueue(t *testing.T) {
+	queue := NewPrintQueue(New(3), 30, 40)
+	split := slices.Index(queue.Lines, "")
+	if split != 30*29/2 {
+		t.Errorf("Expected a rule for every pair, got %d rules", split)
+	}
+	for _, update := range queue.Lines[split+1:] {
+		pages := strings.Split(update, ",")
+		if len(pages)%2 == 0 || len(slices.Compact(slices.Sorted(slices.Values(pages)))) != len(pages) {
+			t.Errorf("Expected an odd number of distinct pages, got %s", update)
+		}
+	}
+	if queue.Ordered == 0 || queue.Reordered == 0 {
+		t.Errorf("Expected updates both in and out of order")
+	}
+}
+
+func TestPresses(t *testing.T) {
+	if a, b, ok := Presses(94, 34, 22, 67, 8400, 5400); !ok || a != 80 || b != 40 {
+		t.Errorf("Expected 80 and 40, got %d, %d, %v", a, b, ok)
+	}
+	if _, _, ok := Presses(26, 66, 67, 21, 12748, 12176); ok {
+		t.Errorf("Expected the second example machine to be unwinnable")
+	}
+
+	machines := NewClawMachines(New(5), 30, 0, 100)
+	if len(machines.Lines) != 30*4-1 || machines.Winnable == 0 || machines.Winnable == 30 {
+		t.Errorf("Expected 30 machines, some winnable, got %d lines and %d winnable", len(machines.Lines), machines.Winnable)
+	}
+}
+
+func TestNewRacetrack(t *testing.T) {
+	for seed := uint64(0); seed < 20; seed++ {
+		track := NewRacetrack(New(seed), 6, 4)
+		if len(track.Lines) != 9 || len(track.Lines[0]) != 13 || track.Lines[1][1] != 'S' || track.Lines[7][11] != 'E' {
+			t.Fatalf("Seed %d: unexpected track\n%s", seed, strings.Join(track.Lines, "\n"))
+		}
+
+		// Every tile of the track but the ends has exactly two neighbors on the track, so there are no forks
+		tiles := 0
+		for row := 1; row < len(track.Lines)-1; row++ {
+			for col := 1; col < len(track.Lines[row])-1; col++ {
+				if track.Lines[row][col] == '#' {
+					continue
+				}
+				tiles++
+				neighbors := 0
+				for _, tile := range []byte{track.Lines[row-1][col], track.Lines[row+1][col], track.Lines[row][col-1], track.Lines[row][col+1]} {
+					if tile != '#' {
+						neighbors++
+					}
+				}
+				end := track.Lines[row][col] != '.'
+				if end && neighbors != 1 || !end && neighbors != 2 {
+					t.Fatalf("Seed %d: %d,%d has %d neighbors\n%s", seed, row, col, neighbors, strings.Join(track.Lines, "\n"))
+				}
+			}
+		}
+		if tiles != track.Length+1 {
+			t.Errorf("Seed %d: expected %d tiles for %d steps, got %d", seed, track.Length+1, track.Length, tiles)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PrintQueue is a Day 5 set of page ordering rules and updates
+//   - Lines: the input, the rules then the updates
+//   - Ordered: the sum of the middle pages of the updates that are already in order, the part 1 answer
+//   - Reordered: the sum of the middle pages of the other updates once they are put in order, the part 2 answer
+type PrintQueue struct {
+	Lines     []string
+	Ordered   int
+	Reordered int
+}
+
+// NewPrintQueue picks a hidden order for pages numbered 10 to 99 and writes a rule for every pair of pages, so every
+// update has exactly one right order. Updates hold an odd number of distinct pages, about half of them in order
+func NewPrintQueue(r *rand.Rand, pages, updates int) PrintQueue {
+	order := r.Perm(90)[:pages]
+	for i := range order {
+		order[i] += 10
+	}
+	rank := make(map[int]int, pages)
+	for i, page := range order {
+		rank[page] = i
+	}
+
+	queue := PrintQueue{Lines: make([]string, 0)}
+	rules := make([]string, 0, pages*(pages-1)/2)
+	for i := range order {
+		for j := i + 1; j < len(order); j++ {
+			rules = append(rules, fmt.Sprintf("%d|%d", order[i], order[j]))
+		}
+	}
+	r.Shuffle(len(rules), func(i, j int) { rules[i], rules[j] = rules[j], rules[i] })
+	queue.Lines = append(queue.Lines, rules...)
+	queue.Lines = append(queue.Lines, "")
+
+	for u := 0; u < updates; u++ {
+		size := 2*between(r, 0, (min(pages, 23)-1)/2) + 1
+		update := slices.Clone(order)
+		r.Shuffle(len(update), func(i, j int) { update[i], update[j] = update[j], update[i] })
+		update = update[:size]
+
+		sorted := slices.Clone(update)
+		slices.SortFunc(sorted, func(a, b int) int { return rank[a] - rank[b] })
+		if r.IntN(2) == 0 {
+			update = sorted
+		}
+		if slices.Equal(update, sorted) {
+			queue.Ordered += sorted[size/2]
+		} else {
+			queue.Reordered += sorted[size/2]
+		}
+
+		values := make([]string, size)
+		for i, page := range update {
+			values[i] = strconv.Itoa(page)
+		}
+		queue.Lines = append(queue.Lines, strings.Join(values, ","))
+	}
+	return queue
+}
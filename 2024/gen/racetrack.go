@@ -0,0 +1,66 @@
+package gen
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+// Racetrack is a Day 20 racetrack
+//   - Lines: the input, a wall of # with a single track of . from S to E
+//   - Length: the number of steps from S to E along the track
+type Racetrack struct {
+	Lines  []string
+	Length int
+}
+
+// NewRacetrack carves a random maze out of a grid of width x height rooms with walls between them, then keeps only the
+// way from the top left room to the bottom right one. What is left is a track with no forks, winding through walls
+// one tile thick, which is what makes cheating pay off. There have to be at least two rooms
+func NewRacetrack(r *rand.Rand, width, height int) Racetrack {
+	grid := make([][]byte, 2*height+1)
+	for row := range grid {
+		grid[row] = []byte(strings.Repeat("#", 2*width+1))
+	}
+
+	// parent links every room to the room it was carved from, the start room links to itself
+	parent := make(map[[2]int][2]int)
+	start, end := [2]int{0, 0}, [2]int{height - 1, width - 1}
+	parent[start] = start
+	stack := [][2]int{start}
+	for len(stack) > 0 {
+		room := stack[len(stack)-1]
+		neighbors := make([][2]int, 0, 4)
+		for _, d := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			next := [2]int{room[0] + d[0], room[1] + d[1]}
+			if _, carved := parent[next]; !carved && next[0] >= 0 && next[0] < height && next[1] >= 0 && next[1] < width {
+				neighbors = append(neighbors, next)
+			}
+		}
+		if len(neighbors) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		next := neighbors[r.IntN(len(neighbors))]
+		parent[next] = room
+		stack = append(stack, next)
+	}
+
+	track := Racetrack{}
+	for room := end; ; room = parent[room] {
+		grid[2*room[0]+1][2*room[1]+1] = '.'
+		if room == start {
+			break
+		}
+		from := parent[room]
+		grid[room[0]+from[0]+1][room[1]+from[1]+1] = '.'
+		track.Length += 2
+	}
+	grid[1][1] = 'S'
+	grid[2*end[0]+1][2*end[1]+1] = 'E'
+
+	track.Lines = make([]string, len(grid))
+	for row := range grid {
+		track.Lines[row] = string(grid[row])
+	}
+	return track
+}
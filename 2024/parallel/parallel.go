@@ -0,0 +1,107 @@
+package parallel
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Number is anything Sum can add up
+type Number interface {
+	~int | ~int32 | ~int64 | ~uint | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+// Map calls fn on every item with a pool of GOMAXPROCS workers and returns the results in the same order as items.
+//
+// The first error cancels the context handed to the calls still running, no new items are started and the error is
+// returned. If ctx is cancelled before every item is done its error is returned instead
+func Map[T, R any](ctx context.Context, items []T, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	err := run(ctx, len(items), func(ctx context.Context, i int) error {
+		result, err := fn(ctx, items[i])
+		if err != nil {
+			return err
+		}
+		results[i] = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// MapReduce maps every item in parallel like Map, then folds the results into initial in item order. Since the fold
+// runs on a single goroutine in a fixed order, reduce doesn't have to be commutative or safe for concurrent use
+func MapReduce[T, R, A any](ctx context.Context, items []T, fn func(ctx context.Context, item T) (R, error), initial A, reduce func(acc A, result R) A) (A, error) {
+	results, err := Map(ctx, items, fn)
+	if err != nil {
+		return initial, err
+	}
+
+	acc := initial
+	for _, result := range results {
+		acc = reduce(acc, result)
+	}
+	return acc, nil
+}
+
+// ForEach calls fn on every item with a pool of GOMAXPROCS workers, stopping at the first error like Map
+func ForEach[T any](ctx context.Context, items []T, fn func(ctx context.Context, item T) error) error {
+	return run(ctx, len(items), func(ctx context.Context, i int) error {
+		return fn(ctx, items[i])
+	})
+}
+
+// Sum is a reducer for MapReduce that adds the results up
+func Sum[N Number](total, value N) N {
+	return total + value
+}
+
+// run hands the indices 0..n-1 out to the worker pool, workers pull the next index as soon as they are free so slow
+// items don't hold up the rest
+func run(ctx context.Context, n int, task func(ctx context.Context, i int) error) error {
+	if n == 0 {
+		return ctx.Err()
+	}
+
+	poolCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		next     atomic.Int64
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for w := 0; w < min(runtime.GOMAXPROCS(0), n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for poolCtx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if err := task(poolCtx, i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	// a cancelled parent means some items may never have run
+	if int(next.Load()) < n {
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMap_KeepsOrder(t *testing.T) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+
+	results, err := Map(context.Background(), items, func(_ context.Context, item int) (int, error) {
+		// make later items finish first
+		if item%7 == 0 {
+			time.Sleep(time.Microsecond)
+		}
+		return item * item, nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, result := range results {
+		if result != i*i {
+			t.Fatalf("Expected %d at index %d, got %d", i*i, i, result)
+		}
+	}
+}
+
+func TestMap_Empty(t *testing.T) {
+	results, err := Map(context.Background(), []int{}, func(_ context.Context, item int) (int, error) {
+		return item, nil
+	})
+	if err != nil || len(results) != 0 {
+		t.Errorf("Expected no results, got %v, %v", results, err)
+	}
+}
+
+func TestMap_FirstErrorStopsTheRest(t *testing.T) {
+	boom := errors.New("boom")
+	items := make([]int, 10000)
+	var started atomic.Int64
+
+	_, err := Map(context.Background(), items, func(ctx context.Context, _ int) (int, error) {
+		if started.Add(1) == 10 {
+			return 0, boom
+		}
+		return 0, nil
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("Expected boom, got %v", err)
+	}
+	if started.Load() == int64(len(items)) {
+		t.Errorf("Expected the error to stop new items from starting")
+	}
+}
+
+func TestMap_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Map(ctx, []int{1, 2, 3}, func(_ context.Context, item int) (int, error) {
+		return item, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestMapReduce_FoldsInOrder(t *testing.T) {
+	words := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	joined, err := MapReduce(context.Background(), words, func(_ context.Context, word string) (string, error) {
+		return word + word, nil
+	}, "", func(acc, word string) string {
+		return acc + word
+	})
+	if err != nil || joined != "aabbccddeeffgghh" {
+		t.Errorf("Expected aabbccddeeffgghh, got %q, %v", joined, err)
+	}
+
+	total, _ := MapReduce(context.Background(), []int{1, 2, 3, 4}, func(_ context.Context, n int) (int, error) {
+		return n * 10, nil
+	}, 0, Sum[int])
+	if total != 100 {
+		t.Errorf("Expected 100, got %d", total)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	seen := make([]bool, 100)
+	items := make([]int, len(seen))
+	for i := range items {
+		items[i] = i
+	}
+
+	err := ForEach(context.Background(), items, func(_ context.Context, item int) error {
+		seen[item] = true
+		return nil
+	})
+	if err != nil || slices.Contains(seen, false) {
+		t.Errorf("Expected every item to be visited, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package puzzle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ExamplesFile lists the examples in a testdata directory
+const ExamplesFile = "examples.json"
+
+// Example is a test fixture, an example input and the answer a part should give for it
+//   - Part: the part the answer is for
+//   - Input: file holding the example input, relative to the testdata directory
+//   - Answer: the answer as it is written on the page
+type Example struct {
+	Part   int    `json:"part"`
+	Input  string `json:"input"`
+	Answer string `json:"answer"`
+}
+
+// Examples picks the example of every part that has an answer. The first block of a part is taken as its example,
+// which is how most puzzles are written, and part 2 reuses the example of part 1 when it doesn't bring its own.
+// Every block is returned so fixtures can be corrected by hand when the guess is wrong
+func (p Page) Examples() ([]string, []Example) {
+	blocks := make([]string, 0)
+	names := make(map[string]string)
+	name := func(block string) string {
+		if existing, ok := names[block]; ok {
+			return existing
+		}
+		blocks = append(blocks, block)
+		names[block] = fmt.Sprintf("example%d.txt", len(blocks))
+		return names[block]
+	}
+
+	examples := make([]Example, 0)
+	input := ""
+	for i, part := range p.Parts {
+		for _, block := range part.Blocks {
+			name(block)
+		}
+		if len(part.Blocks) > 0 {
+			input = names[part.Blocks[0]]
+		}
+		if part.Answer != "" && input != "" {
+			examples = append(examples, Example{Part: i + 1, Input: input, Answer: part.Answer})
+		}
+	}
+	return blocks, examples
+}
+
+// WriteFixtures writes every block of the page to exampleN.txt in dir and the examples to examples.json. Fixtures are
+// often fixed by hand and fuzz targets seed from them, so unless force is set nothing is written when any of the files
+// is already there, and the error wraps os.ErrExist
+func WriteFixtures(dir string, page Page, force bool) ([]Example, error) {
+	blocks, examples := page.Examples()
+	paths := make([]string, len(blocks))
+	for i := range blocks {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("example%d.txt", i+1))
+	}
+	examplesPath := filepath.Join(dir, ExamplesFile)
+
+	if !force {
+		for _, path := range append(paths, examplesPath) {
+			if _, err := os.Stat(path); err == nil {
+				return nil, fmt.Errorf("%s: %w", path, os.ErrExist)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	for i, block := range blocks {
+		if err := os.WriteFile(paths[i], []byte(block+"\n"), 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.MarshalIndent(examples, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return examples, os.WriteFile(examplesPath, append(data, '\n'), 0o644)
+}
+
+// LoadExamples reads the examples of a testdata directory, no examples file means no examples
+func LoadExamples(dir string) ([]Example, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ExamplesFile))
+	if os.IsNotExist(err) {
+		return []Example{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var examples []Example
+	if err := json.Unmarshal(data, &examples); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ExamplesFile, err)
+	}
+	return examples, nil
+}
+
+// Lines reads the input of the example as lines, the way util.ReadInput reads a puzzle input
+func (e Example) Lines(dir string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, e.Input))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimRight(string(data), "\n"), "\n"), nil
+}
+
+// CheckExamples solves every example in dir and reports the answers that don't match the page. solve gets the example
+// along with its input and returns nil for a part that isn't solved yet. Skips the test when dir has no examples
+func CheckExamples(tb testing.TB, dir string, solve func(example Example, input []string) any) {
+	tb.Helper()
+	examples, err := LoadExamples(dir)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if len(examples) == 0 {
+		tb.Skipf("No examples in %s, run aoc examples first", dir)
+	}
+
+	for _, example := range examples {
+		input, err := example.Lines(dir)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		answer := solve(example, input)
+		if answer == nil {
+			tb.Logf("Part %d isn't solved yet", example.Part)
+			continue
+		}
+		if got := fmt.Sprint(answer); got != example.Answer {
+			tb.Errorf("Part %d of %s: expected %s, got %s", example.Part, example.Input, example.Answer, got)
+		}
+	}
+}
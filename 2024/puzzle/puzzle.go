@@ -0,0 +1,63 @@
+package puzzle
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	titlePattern   = regexp.MustCompile(`<h2[^>]*>--- (.*?) ---</h2>`)
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	blockPattern   = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	// answers are emphasized code, the site writes both <code><em>143</em></code> and <em><code>143</code></em>
+	answerPattern = regexp.MustCompile(`<code><em>([^<]*)</em></code>|<em><code>([^<]*)</code></em>`)
+	tagPattern    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Page is a saved puzzle page
+//   - Title: title of the puzzle, like "Day 5: Print Queue"
+//   - Parts: part 1, and part 2 if it was unlocked when the page was saved
+type Page struct {
+	Title string
+	Parts []Part
+}
+
+// Part is one part of the puzzle as it is written on the page
+//   - Blocks: the <pre><code> blocks in the order they appear, examples and the diagrams explaining them
+//   - Answer: the last emphasized code of the part, which is the answer to the example. Empty if there is none
+type Part struct {
+	Blocks []string
+	Answer string
+}
+
+// ParsePage reads the parts out of a puzzle page
+func ParsePage(page string) (Page, error) {
+	articles := articlePattern.FindAllStringSubmatch(page, -1)
+	if len(articles) == 0 {
+		return Page{}, fmt.Errorf("no puzzle description on the page")
+	}
+
+	parsed := Page{Parts: make([]Part, 0, len(articles))}
+	if title := titlePattern.FindStringSubmatch(articles[0][1]); title != nil {
+		parsed.Title = text(title[1])
+	}
+	for _, article := range articles {
+		part := Part{Blocks: make([]string, 0)}
+		for _, block := range blockPattern.FindAllStringSubmatch(article[1], -1) {
+			part.Blocks = append(part.Blocks, strings.TrimRight(text(block[1]), "\n"))
+		}
+		if answers := answerPattern.FindAllStringSubmatch(article[1], -1); len(answers) > 0 {
+			last := answers[len(answers)-1]
+			part.Answer = strings.TrimSpace(text(last[1] + last[2]))
+		}
+		parsed.Parts = append(parsed.Parts, part)
+	}
+	return parsed, nil
+}
+
+// text strips the tags out of html, the site emphasizes parts of the examples, and unescapes what is left
+func text(s string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(s, ""))
+}
@@ -0,0 +1,130 @@
+package puzzle
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// page is a trimmed down Day 5 page, saved after part 1 was solved
+const page = `<!DOCTYPE html>
+<html lang="en-us"><head><title>Day 5 - Advent of Code 2024</title></head><body>
+<main>
+<article class="day-desc"><h2>--- Day 5: Print Queue ---</h2><p>The notation <code>X|Y</code> means that if both page number <code>X</code> and page number <code>Y</code> are to be produced as part of an update, page number <code>X</code> <em>must</em> be printed at some point before page number <code>Y</code>.</p>
+<p>For example:</p>
+<pre><code>47|53
+97|13
+
+75,47,61,53,29
+97,61,53,29,13
+</code></pre>
+<p>In the above example, the first update (<code>75,47,<em>61</em>,53,29</code>) is in the right order.</p>
+<pre><code><em>75</em>,47,61,53,29
+</code></pre>
+<p>Adding these page numbers together gives <code><em>143</em></code>.</p>
+<p>Determine which updates are already in the correct order. <em>What do you get if you add up the middle page number from those correctly-ordered updates?</em></p>
+</article>
+<p>Your puzzle answer was <code>5248</code>.</p><article class="day-desc"><h2 id="part2">--- Part Two ---</h2><p>For each of the <em>incorrectly-ordered updates</em>, use the page ordering rules to put the page numbers in the right order. For the above example, here are the three incorrectly-ordered updates and their correct orderings:</p>
+<ul>
+<li><code>75,97,47,61,53</code> becomes <code>97,75,<em>47</em>,61,53</code>.</li>
+</ul>
+<p>After taking <em>only the incorrectly-ordered updates</em> and ordering them correctly, their middle page numbers are <code>47</code>, <code>29</code>, and <code>47</code>. Adding these together produces <em><code>123</code></em>.</p>
+<p>Find the updates which are not in the correct order. <em>What do you get if you add up the middle page numbers after correctly ordering just those updates?</em></p>
+</article>
+</main></body></html>`
+
+func TestParsePage(t *testing.T) {
+	parsed, err := ParsePage(page)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if parsed.Title != "Day 5: Print Queue" {
+		t.Errorf("Unexpected title %q", parsed.Title)
+	}
+	if len(parsed.Parts) != 2 {
+		t.Fatalf("Expected 2 parts, got %d", len(parsed.Parts))
+	}
+
+	first := parsed.Parts[0]
+	if len(first.Blocks) != 2 || first.Blocks[0] != "47|53\n97|13\n\n75,47,61,53,29\n97,61,53,29,13" {
+		t.Errorf("Unexpected blocks %q", first.Blocks)
+	}
+	if first.Blocks[1] != "75,47,61,53,29" {
+		t.Errorf("Expected the emphasis to be stripped from blocks, got %q", first.Blocks[1])
+	}
+	if first.Answer != "143" || parsed.Parts[1].Answer != "123" {
+		t.Errorf("Expected answers 143 and 123, got %q and %q", first.Answer, parsed.Parts[1].Answer)
+	}
+
+	if _, err := ParsePage("<html>Not logged in</html>"); err == nil {
+		t.Errorf("Expected an error for a page without a puzzle")
+	}
+}
+
+func TestExamples(t *testing.T) {
+	parsed, _ := ParsePage(page)
+	blocks, examples := parsed.Examples()
+	if len(blocks) != 2 {
+		t.Errorf("Expected 2 distinct blocks, got %d", len(blocks))
+	}
+	want := []Example{{Part: 1, Input: "example1.txt", Answer: "143"}, {Part: 2, Input: "example1.txt", Answer: "123"}}
+	if !slices.Equal(examples, want) {
+		t.Errorf("Expected %v, got %v", want, examples)
+	}
+
+	// a part 2 with its own example uses it
+	parsed.Parts[1].Blocks = []string{"1|2\n\n1,2"}
+	_, examples = parsed.Examples()
+	if examples[1].Input != "example3.txt" {
+		t.Errorf("Expected part 2 to use its own example, got %s", examples[1].Input)
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "testdata")
+	if examples, err := LoadExamples(dir); err != nil || len(examples) != 0 {
+		t.Errorf("Expected no examples before writing any, got %v, %v", examples, err)
+	}
+
+	parsed, _ := ParsePage(page)
+	written, err := WriteFixtures(dir, parsed, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	loaded, err := LoadExamples(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(written, loaded) {
+		t.Errorf("Expected %v, got %v", written, loaded)
+	}
+
+	lines, err := loaded[0].Lines(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(lines) != 5 || lines[2] != "" || lines[4] != "97,61,53,29,13" {
+		t.Errorf("Unexpected example lines %q", lines)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "example2.txt")); err != nil {
+		t.Errorf("Expected every block to be written: %v", err)
+	}
+	// a fixture fixed by hand is kept unless the fixtures are forced
+	if err := os.WriteFile(filepath.Join(dir, "example1.txt"), []byte("fixed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WriteFixtures(dir, parsed, false); !errors.Is(err, os.ErrExist) {
+		t.Errorf("Expected an error for fixtures that are already there, got %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "example1.txt")); string(data) != "fixed\n" {
+		t.Errorf("Expected the fixed example to be kept, got %q", data)
+	}
+	if _, err := WriteFixtures(dir, parsed, true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "example1.txt")); string(data) == "fixed\n" {
+		t.Error("Expected -force to overwrite the example")
+	}
+}
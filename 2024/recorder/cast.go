@@ -0,0 +1,61 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const clearScreen = "\x1b[2J\x1b[H"
+
+// CastOptions configure the asciinema cast file
+//   - FrameDelay: time between frames
+//   - Title: title stored in the cast header
+type CastOptions struct {
+	FrameDelay time.Duration
+	Title      string
+}
+
+// DefaultCastOptions shows 10 frames per second
+var DefaultCastOptions = CastOptions{FrameDelay: 100 * time.Millisecond}
+
+// castHeader is the first line of an asciicast v2 file
+type castHeader struct {
+	Version int    `json:"version"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Title   string `json:"title,omitempty"`
+}
+
+// WriteCast writes the frames as an asciicast v2 file, which asciinema can play back. Every frame clears the screen
+// and prints its annotations above the grid
+func (r *Recorder) WriteCast(w io.Writer, opts CastOptions) error {
+	width, height := r.size()
+	for _, frame := range r.frames {
+		for _, annotation := range frame.Annotations {
+			width = max(width, len(annotation))
+		}
+		height = max(height, len(frame.Grid)+len(frame.Annotations))
+	}
+
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(castHeader{Version: 2, Width: width, Height: height, Title: opts.Title}); err != nil {
+		return err
+	}
+
+	for i, frame := range r.frames {
+		at := (time.Duration(i) * opts.FrameDelay).Seconds()
+		if err := encoder.Encode([]interface{}{at, "o", clearScreen + render(frame, "\r\n")}); err != nil {
+			return fmt.Errorf("unable to write frame %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// render draws the annotations followed by the grid, joined by newline
+func render(frame Frame, newline string) string {
+	lines := append(append([]string{}, frame.Annotations...), frame.Grid...)
+	return strings.Join(lines, newline) + newline
+}
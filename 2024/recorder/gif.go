@@ -0,0 +1,196 @@
+package recorder
+
+import (
+	"bufio"
+	"compress/lzw"
+	"fmt"
+	"image/color"
+	"io"
+)
+
+// GIFOptions configure how frames are drawn
+//   - CellSize: width and height in pixels of a single grid cell
+//   - Delay: time each frame is shown, in 100ths of a second
+//   - Colors: color of each grid character, characters without a color get one picked from the palette.
+//     Annotations aren't drawn in a GIF
+type GIFOptions struct {
+	CellSize int
+	Delay    int
+	Colors   map[rune]color.Color
+}
+
+// DefaultGIFOptions colors the characters the grid puzzles use
+var DefaultGIFOptions = GIFOptions{
+	CellSize: 4,
+	Delay:    10,
+	Colors: map[rune]color.Color{
+		'.': color.RGBA{0x10, 0x10, 0x18, 0xff},
+		'#': color.RGBA{0x80, 0x80, 0x88, 0xff},
+		'@': color.RGBA{0xff, 0xd7, 0x00, 0xff},
+		'O': color.RGBA{0xa0, 0x60, 0x20, 0xff},
+		'[': color.RGBA{0xa0, 0x60, 0x20, 0xff},
+		']': color.RGBA{0xa0, 0x60, 0x20, 0xff},
+		'X': color.RGBA{0x20, 0xc0, 0x40, 0xff},
+		'^': color.RGBA{0xe0, 0x30, 0x30, 0xff},
+		'>': color.RGBA{0xe0, 0x30, 0x30, 0xff},
+		'v': color.RGBA{0xe0, 0x30, 0x30, 0xff},
+		'<': color.RGBA{0xe0, 0x30, 0x30, 0xff},
+	},
+}
+
+// fallback is used for characters without a configured color
+var fallback = []color.Color{
+	color.RGBA{0x30, 0x90, 0xe0, 0xff},
+	color.RGBA{0xe0, 0x80, 0x30, 0xff},
+	color.RGBA{0x90, 0x40, 0xd0, 0xff},
+	color.RGBA{0x40, 0xd0, 0xd0, 0xff},
+	color.RGBA{0xd0, 0xd0, 0x40, 0xff},
+	color.RGBA{0xd0, 0x40, 0x90, 0xff},
+}
+
+// WriteGIF draws every frame as an animated GIF, one block of pixels per grid cell. Each frame is drawn and
+// compressed a row of pixels at a time, so a long recording never holds more than one row of pixels in memory
+func (r *Recorder) WriteGIF(w io.Writer, opts GIFOptions) error {
+	if len(r.frames) == 0 {
+		return fmt.Errorf("no frames were recorded")
+	}
+	if opts.CellSize <= 0 {
+		return fmt.Errorf("invalid cell size %d", opts.CellSize)
+	}
+
+	palette, indices := r.palette(opts.Colors)
+	columns, rows := r.size()
+	width, height := columns*opts.CellSize, rows*opts.CellSize
+	if width > 0xffff || height > 0xffff {
+		return fmt.Errorf("frames of %dx%d pixels are too big for a GIF", width, height)
+	}
+	depth := 1
+	for 1<<depth < len(palette) {
+		depth++
+	}
+
+	out := bufio.NewWriter(w)
+	out.WriteString("GIF89a")
+	writeUint16(out, width, height)
+	// global color table of 2^depth colors, followed by the background color index and the pixel aspect ratio
+	out.Write([]byte{0x80 | 0x70 | byte(depth-1), 0, 0})
+	for i := 0; i < 1<<depth; i++ {
+		c := color.Color(color.Black)
+		if i < len(palette) {
+			c = palette[i]
+		}
+		red, green, blue, _ := c.RGBA()
+		out.Write([]byte{byte(red >> 8), byte(green >> 8), byte(blue >> 8)})
+	}
+	// loop forever
+	out.Write([]byte{0x21, 0xff, 0x0b})
+	out.WriteString("NETSCAPE2.0")
+	out.Write([]byte{0x03, 0x01, 0x00, 0x00, 0x00})
+
+	row := make([]byte, width)
+	for i, frame := range r.frames {
+		out.Write([]byte{0x21, 0xf9, 0x04, 0x00})
+		writeUint16(out, opts.Delay)
+		out.Write([]byte{0x00, 0x00, 0x2c})
+		writeUint16(out, 0, 0, width, height)
+		literal := max(depth, 2)
+		out.Write([]byte{0x00, byte(literal)})
+
+		blocks := &subBlocks{w: out}
+		compressor := lzw.NewWriter(blocks, lzw.LSB, literal)
+		for y := 0; y < height; y++ {
+			clear(row)
+			if y/opts.CellSize < len(frame.Grid) {
+				for x, char := range []rune(frame.Grid[y/opts.CellSize]) {
+					for px := x * opts.CellSize; px < (x+1)*opts.CellSize; px++ {
+						row[px] = indices[char]
+					}
+				}
+			}
+			if _, err := compressor.Write(row); err != nil {
+				return fmt.Errorf("unable to write frame %d: %w", i, err)
+			}
+		}
+		if err := compressor.Close(); err != nil {
+			return fmt.Errorf("unable to write frame %d: %w", i, err)
+		}
+		if err := blocks.Close(); err != nil {
+			return fmt.Errorf("unable to write frame %d: %w", i, err)
+		}
+	}
+	out.WriteByte(0x3b)
+	return out.Flush()
+}
+
+// writeUint16 writes each value as the two little endian bytes GIF uses for sizes and delays
+func writeUint16(w *bufio.Writer, values ...int) {
+	for _, v := range values {
+		w.Write([]byte{byte(v), byte(v >> 8)})
+	}
+}
+
+// subBlocks splits the compressed image data into the blocks of up to 255 bytes GIF stores it in, each one led by
+// its length. Close writes what is left and the empty block that ends the data
+type subBlocks struct {
+	w      io.Writer
+	buffer [255]byte
+	n      int
+}
+
+func (b *subBlocks) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		copied := copy(b.buffer[b.n:], p)
+		b.n += copied
+		written += copied
+		p = p[copied:]
+		if b.n == len(b.buffer) {
+			if err := b.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (b *subBlocks) flush() error {
+	if b.n == 0 {
+		return nil
+	}
+	if _, err := b.w.Write([]byte{byte(b.n)}); err != nil {
+		return err
+	}
+	_, err := b.w.Write(b.buffer[:b.n])
+	b.n = 0
+	return err
+}
+
+func (b *subBlocks) Close() error {
+	if err := b.flush(); err != nil {
+		return err
+	}
+	_, err := b.w.Write([]byte{0})
+	return err
+}
+
+// palette builds the color palette for every character used in the frames, index 0 is the background
+func (r *Recorder) palette(colors map[rune]color.Color) (color.Palette, map[rune]uint8) {
+	palette := color.Palette{color.Black}
+	indices := make(map[rune]uint8)
+	for _, frame := range r.frames {
+		for _, line := range frame.Grid {
+			for _, char := range line {
+				if _, ok := indices[char]; ok || len(palette) == 256 {
+					continue
+				}
+				c, ok := colors[char]
+				if !ok {
+					c = fallback[int(char)%len(fallback)]
+				}
+				indices[char] = uint8(len(palette))
+				palette = append(palette, c)
+			}
+		}
+	}
+	return palette, indices
+}
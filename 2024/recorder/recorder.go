@@ -0,0 +1,143 @@
+package recorder
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Replay is the -record value that steps through the frames in the terminal instead of writing a file
+const Replay = "replay"
+
+var destination = flag.String("record", "", "Record grid frames to a .gif or .cast file, or \"replay\" to step through them in the terminal")
+
+// Frame is a snapshot of a grid along with notes about what is happening in it
+type Frame struct {
+	Grid        []string
+	Annotations []string
+}
+
+// Recorder collects frames from a solver and turns them into an animation once the solver is done.
+// A nil Recorder is valid and ignores everything, so solvers can capture frames unconditionally
+//   - limit: the most frames kept, 0 keeps every frame
+//   - dropped: frames captured after the limit was reached
+type Recorder struct {
+	destination string
+	frames      []Frame
+	limit       int
+	dropped     int
+}
+
+// New creates a Recorder that writes to destination when closed. The file type is picked from the extension,
+// or the frames are replayed in the terminal if destination is Replay
+func New(destination string) *Recorder {
+	return &Recorder{destination: destination, frames: make([]Frame, 0)}
+}
+
+// FromFlags creates a Recorder for the -record flag, returns nil if the flag wasn't set.
+// Needs to be called after flag.Parse (util.Parameter parses the flags)
+func FromFlags() *Recorder {
+	if *destination == "" {
+		return nil
+	}
+	return New(*destination)
+}
+
+// Enabled reports whether frames are being recorded, useful to skip building expensive snapshots
+func (r *Recorder) Enabled() bool {
+	return r != nil
+}
+
+// Limit caps how many frames are kept, frames captured once there are that many are dropped. It keeps a long running
+// solver from filling the memory with its recording
+func (r *Recorder) Limit(frames int) {
+	if r == nil {
+		return
+	}
+	r.limit = frames
+}
+
+// Dropped returns how many frames were captured after the limit and not kept
+func (r *Recorder) Dropped() int {
+	if r == nil {
+		return 0
+	}
+	return r.dropped
+}
+
+// Capture records a snapshot of the grid
+func (r *Recorder) Capture(grid [][]string, annotations ...string) {
+	if r == nil {
+		return
+	}
+	lines := make([]string, len(grid))
+	for i, row := range grid {
+		lines[i] = strings.Join(row, "")
+	}
+	r.CaptureLines(lines, annotations...)
+}
+
+// CaptureLines records a snapshot of a grid that is already rendered as one string per row
+func (r *Recorder) CaptureLines(lines []string, annotations ...string) {
+	if r == nil {
+		return
+	}
+	if r.limit > 0 && len(r.frames) >= r.limit {
+		r.dropped++
+		return
+	}
+	r.frames = append(r.frames, Frame{Grid: append([]string{}, lines...), Annotations: append([]string{}, annotations...)})
+}
+
+// Frames returns every frame recorded so far
+func (r *Recorder) Frames() []Frame {
+	if r == nil {
+		return nil
+	}
+	return r.frames
+}
+
+// Close writes the recorded frames to the destination, or starts the terminal replay
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	if r.destination == Replay {
+		return r.Replay(os.Stdin, os.Stdout)
+	}
+
+	var write func(io.Writer) error
+	switch strings.ToLower(filepath.Ext(r.destination)) {
+	case ".gif":
+		write = func(w io.Writer) error { return r.WriteGIF(w, DefaultGIFOptions) }
+	case ".cast":
+		write = func(w io.Writer) error { return r.WriteCast(w, DefaultCastOptions) }
+	default:
+		return fmt.Errorf("unknown recording format %q, expected .gif or .cast", filepath.Ext(r.destination))
+	}
+
+	file, err := os.Create(r.destination)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := write(file); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// size returns the widest row and the most rows across every frame
+func (r *Recorder) size() (int, int) {
+	width, height := 0, 0
+	for _, frame := range r.frames {
+		height = max(height, len(frame.Grid))
+		for _, line := range frame.Grid {
+			width = max(width, len(line))
+		}
+	}
+	return width, height
+}
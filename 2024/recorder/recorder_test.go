@@ -0,0 +1,168 @@
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sample() *Recorder {
+	r := New("unused")
+	r.Capture([][]string{{"#", "."}, {".", "#"}}, "Second: 1")
+	r.CaptureLines([]string{"..", "##", "@."}, "Second: 2")
+	r.CaptureLines([]string{"O"})
+	return r
+}
+
+func TestRecorder_NilIgnoresEverything(t *testing.T) {
+	var r *Recorder
+	r.Capture([][]string{{"#"}})
+	r.CaptureLines([]string{"#"})
+	if r.Enabled() || r.Frames() != nil {
+		t.Errorf("Expected nil recorder to be disabled and hold no frames")
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("Expected nil recorder to close cleanly, got %v", err)
+	}
+}
+
+func TestRecorder_CaptureCopiesGrid(t *testing.T) {
+	r := New("unused")
+	grid := [][]string{{"#", "."}}
+	r.Capture(grid, "first")
+	grid[0][0] = "."
+
+	frames := r.Frames()
+	if len(frames) != 1 || frames[0].Grid[0] != "#." || frames[0].Annotations[0] != "first" {
+		t.Errorf("Expected captured frame to keep the original grid, got %+v", frames)
+	}
+}
+
+func TestRecorder_WriteCast(t *testing.T) {
+	var out bytes.Buffer
+	if err := sample().WriteCast(&out, DefaultCastOptions); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	scanner := bufio.NewScanner(&out)
+	scanner.Scan()
+	var header castHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		t.Fatalf("Invalid header: %v", err)
+	}
+	if header.Version != 2 || header.Width != 9 || header.Height != 4 {
+		t.Errorf("Expected version 2 header sized 9x4, got %+v", header)
+	}
+
+	events := 0
+	for scanner.Scan() {
+		var event []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("Invalid event %q: %v", scanner.Text(), err)
+		}
+		if event[1] != "o" {
+			t.Errorf("Expected output event, got %v", event[1])
+		}
+		events++
+	}
+	if events != 3 {
+		t.Errorf("Expected 3 events, got %d", events)
+	}
+}
+
+func TestRecorder_WriteGIF(t *testing.T) {
+	var out bytes.Buffer
+	opts := DefaultGIFOptions
+	opts.CellSize = 2
+	if err := sample().WriteGIF(&out, opts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	decoded, err := gif.DecodeAll(&out)
+	if err != nil {
+		t.Fatalf("Invalid gif: %v", err)
+	}
+	if len(decoded.Image) != 3 {
+		t.Errorf("Expected 3 frames, got %d", len(decoded.Image))
+	}
+	if bounds := decoded.Image[0].Bounds(); bounds.Dx() != 4 || bounds.Dy() != 6 {
+		t.Errorf("Expected 4x6 frames, got %v", bounds)
+	}
+	// the first frame is #. over .#, the rows it doesn't have are background
+	wall, floor, background := opts.Colors['#'], opts.Colors['.'], color.Black
+	for _, pixel := range []struct {
+		x, y int
+		want color.Color
+	}{{1, 1, wall}, {2, 1, floor}, {3, 3, wall}, {0, 5, background}} {
+		if !sameColor(decoded.Image[0].At(pixel.x, pixel.y), pixel.want) {
+			t.Errorf("Expected %v at %d,%d, got %v", pixel.want, pixel.x, pixel.y, decoded.Image[0].At(pixel.x, pixel.y))
+		}
+	}
+	if decoded.Delay[2] != opts.Delay {
+		t.Errorf("Expected a delay of %d, got %d", opts.Delay, decoded.Delay[2])
+	}
+}
+
+func sameColor(x, y color.Color) bool {
+	xr, xg, xb, xa := x.RGBA()
+	yr, yg, yb, ya := y.RGBA()
+	return xr == yr && xg == yg && xb == yb && xa == ya
+}
+
+func TestRecorder_Limit(t *testing.T) {
+	r := New("unused")
+	r.Limit(2)
+	for i := 0; i < 5; i++ {
+		r.CaptureLines([]string{"#"})
+	}
+	if len(r.Frames()) != 2 || r.Dropped() != 3 {
+		t.Errorf("Expected 2 frames kept and 3 dropped, got %d and %d", len(r.Frames()), r.Dropped())
+	}
+}
+
+func TestRecorder_Close_UnknownFormat(t *testing.T) {
+	destination := filepath.Join(t.TempDir(), "frames.mp4")
+	r := New(destination)
+	r.CaptureLines([]string{"#"})
+	if err := r.Close(); err == nil {
+		t.Errorf("Expected an error for an .mp4 recording")
+	}
+	if _, err := os.Stat(destination); !os.IsNotExist(err) {
+		t.Errorf("Expected no file to be left behind, got %v", err)
+	}
+}
+
+func TestRecorder_Replay(t *testing.T) {
+	var out bytes.Buffer
+	commands := strings.Join([]string{"", "l", "p", "g 1", "q"}, "\n")
+	if err := sample().Replay(strings.NewReader(commands), &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{"frame 1/3", "frame 2/3", "frame 3/3", "frame 2/3", "frame 1/3"}
+	got := make([]string, 0)
+	for _, line := range strings.Split(out.String(), "\n") {
+		if i := strings.Index(line, "frame "); i != -1 {
+			got = append(got, line[i:])
+		}
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected frames %v, got %v", want, got)
+	}
+
+	out.Reset()
+	if err := sample().Replay(strings.NewReader("x\ng two\nn\nq\n"), &out); err != nil {
+		t.Fatalf("Expected the replay to go on after a bad command, got %v", err)
+	}
+	for _, want := range []string{"unknown command \"x\"", "invalid frame \"two\"", "frame 2/3"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in the replay, got\n%s", want, out.String())
+		}
+	}
+}
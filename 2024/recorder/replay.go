@@ -0,0 +1,78 @@
+package recorder
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const replayHelp = "commands: [enter]/n next, p previous, g <frame> seek, f first, l last, q quit"
+
+// Replay steps through the frames in the terminal, reading commands from in and drawing frames to out. A command it
+// doesn't understand is reported under the frame it was given on, so a typo doesn't end the replay
+func (r *Recorder) Replay(in io.Reader, out io.Writer) error {
+	if len(r.frames) == 0 {
+		_, err := fmt.Fprintln(out, "no frames were recorded")
+		return err
+	}
+
+	scanner := bufio.NewScanner(in)
+	current := 0
+	problem := ""
+	for {
+		frame := r.frames[current]
+		fmt.Fprintf(out, "%sframe %d/%d\n%s%s\n", clearScreen, current+1, len(r.frames), render(frame, "\n"), replayHelp)
+		if problem != "" {
+			fmt.Fprintln(out, problem)
+		}
+		fmt.Fprint(out, "> ")
+
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+
+		next, quit, err := r.seek(current, strings.Fields(scanner.Text()))
+		problem = ""
+		if err != nil {
+			problem = err.Error()
+		}
+		if quit {
+			return nil
+		}
+		current = next
+	}
+}
+
+// seek works out which frame a replay command moves to, frames are clamped to the recording
+func (r *Recorder) seek(current int, command []string) (int, bool, error) {
+	last := len(r.frames) - 1
+	if len(command) == 0 {
+		return min(current+1, last), false, nil
+	}
+
+	switch command[0] {
+	case "n":
+		return min(current+1, last), false, nil
+	case "p":
+		return max(current-1, 0), false, nil
+	case "f":
+		return 0, false, nil
+	case "l":
+		return last, false, nil
+	case "q":
+		return current, true, nil
+	case "g":
+		if len(command) != 2 {
+			return current, false, fmt.Errorf("usage: g <frame>")
+		}
+		frame, err := strconv.Atoi(command[1])
+		if err != nil {
+			return current, false, fmt.Errorf("invalid frame %q", command[1])
+		}
+		return min(max(frame-1, 0), last), false, nil
+	default:
+		return current, false, fmt.Errorf("unknown command %q, %s", command[0], replayHelp)
+	}
+}
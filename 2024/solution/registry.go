@@ -0,0 +1,70 @@
+package solution
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Solver is a day the aoc runner can run. Days made with aoc new are solvers and register themselves from init
+//   - Parse: reads the puzzle input, called once before the parts
+//   - Part1, Part2: return the answers, nil while a part isn't solved yet
+type Solver interface {
+	Parse(input []string) error
+	Part1() any
+	Part2() any
+}
+
+// Puzzle identifies a day of a year
+type Puzzle struct {
+	Year int
+	Day  int
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[Puzzle]func() Solver)
+)
+
+// Register makes a solver available to the runner, newSolver is called for every run so solvers can keep their
+// parsed input in fields. Registering the same day twice panics, like registering a database driver twice
+func Register(year, day int, newSolver func() Solver) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	puzzle := Puzzle{Year: year, Day: day}
+	if _, exists := registry[puzzle]; exists {
+		panic(fmt.Sprintf("solution: day %d of %d is registered twice", day, year))
+	}
+	registry[puzzle] = newSolver
+}
+
+// Lookup returns a new solver for the day, false if nothing registered it
+func Lookup(year, day int) (Solver, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	newSolver, ok := registry[Puzzle{Year: year, Day: day}]
+	if !ok {
+		return nil, false
+	}
+	return newSolver(), true
+}
+
+// Registered returns every registered day, sorted by year then day
+func Registered() []Puzzle {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	puzzles := make([]Puzzle, 0, len(registry))
+	for puzzle := range registry {
+		puzzles = append(puzzles, puzzle)
+	}
+	sort.Slice(puzzles, func(i, j int) bool {
+		if puzzles[i].Year != puzzles[j].Year {
+			return puzzles[i].Year < puzzles[j].Year
+		}
+		return puzzles[i].Day < puzzles[j].Day
+	})
+	return puzzles
+}
@@ -0,0 +1,166 @@
+package solution
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// Output formats for the -format flag
+const (
+	Text = "text"
+	JSON = "json"
+	TSV  = "tsv"
+)
+
+var (
+	format  = flag.String("format", Text, "How answers are printed: text, json (one object per line) or tsv")
+	quiet   = flag.Bool("quiet", false, "Only log warnings and errors")
+	verbose = flag.Bool("verbose", false, "Log debug messages, like which file is being read")
+)
+
+// flagLevel picks the log level from -quiet and -verbose every time it is asked, so messages logged before
+// the flags are parsed and after both end up at the right level
+type flagLevel struct{}
+
+func (flagLevel) Level() slog.Level {
+	switch {
+	case *verbose:
+		return slog.LevelDebug
+	case *quiet:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// every solver imports this package, so logging goes to stderr and answers are the only thing on stdout
+func init() {
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: flagLevel{}})))
+}
+
+// Result is the answer to one part of a puzzle
+//   - Day, Part: which puzzle the answer is for
+//   - Answer: the answer as it would be typed into the website
+//   - Elapsed: how long the part took to solve, parsing the input is not included
+//   - Notes: anything else worth showing next to the answer
+type Result struct {
+	Day     int           `json:"day"`
+	Part    int           `json:"part"`
+	Answer  string        `json:"answer"`
+	Elapsed time.Duration `json:"elapsed_ns"`
+	Notes   []string      `json:"notes,omitempty"`
+}
+
+// Solution times the parts of a day and prints their results in the format picked with -format
+type Solution struct {
+	day     int
+	format  string
+	out     io.Writer
+	results []Result
+}
+
+// New creates a Solution for the day that prints to stdout. Parses the flags if nothing has yet, so any flags
+// the solver defines itself have to be defined before calling New
+func New(day int) *Solution {
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+	return NewWithOutput(day, *format, os.Stdout)
+}
+
+// NewWithOutput creates a Solution that prints to out in the given format
+func NewWithOutput(day int, format string, out io.Writer) *Solution {
+	if format != Text && format != JSON && format != TSV {
+		slog.Warn("Unknown output format, printing text instead", "format", format)
+		format = Text
+	}
+	return &Solution{day: day, format: format, out: out, results: make([]Result, 0)}
+}
+
+// Part solves a part and prints its result. A nil answer means the part has no answer to give
+func (s *Solution) Part(part int, solve func() any) Result {
+	return s.PartWithNotes(part, func() (any, []string) {
+		return solve(), nil
+	})
+}
+
+// PartWithNotes solves a part that has more to say than its answer
+func (s *Solution) PartWithNotes(part int, solve func() (any, []string)) Result {
+	start := time.Now()
+	answer, notes := solve()
+	result := Result{Day: s.day, Part: part, Elapsed: time.Since(start), Notes: notes}
+	if answer != nil {
+		result.Answer = fmt.Sprint(answer)
+	}
+
+	s.results = append(s.results, result)
+	if err := s.print(result); err != nil {
+		slog.Error("Unable to print result", "day", s.day, "part", part, "err", err)
+	}
+	return result
+}
+
+// Solve parses the input with the solver and solves both of its parts
+func (s *Solution) Solve(solver Solver, input []string) error {
+	if err := solver.Parse(input); err != nil {
+		return fmt.Errorf("unable to parse input: %w", err)
+	}
+	s.Part(1, solver.Part1)
+	s.Part(2, solver.Part2)
+	return nil
+}
+
+// Results returns every result so far, in the order the parts were solved
+func (s *Solution) Results() []Result {
+	return append([]Result{}, s.results...)
+}
+
+func (s *Solution) print(result Result) error {
+	switch s.format {
+	case JSON:
+		return json.NewEncoder(s.out).Encode(result)
+	case TSV:
+		// the header goes above the first result only
+		if len(s.results) == 1 {
+			if _, err := fmt.Fprintln(s.out, "day\tpart\tanswer\telapsed_ns\tnotes"); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(s.out, "%d\t%d\t%s\t%d\t%s\n", result.Day, result.Part, result.Answer, result.Elapsed.Nanoseconds(), strings.Join(result.Notes, "; "))
+		return err
+	default:
+		answer := result.Answer
+		if answer == "" {
+			answer = "(no answer)"
+		}
+		if _, err := fmt.Fprintf(s.out, "Day %d part %d: %s (%s)\n", result.Day, result.Part, answer, result.Elapsed.Round(time.Microsecond)); err != nil {
+			return err
+		}
+		for _, note := range result.Notes {
+			if _, err := fmt.Fprintf(s.out, "    %s\n", note); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Parse reads results printed with the JSON format, for tools that run a solver and need its answers
+func Parse(r io.Reader) ([]Result, error) {
+	results := make([]Result, 0)
+	decoder := json.NewDecoder(r)
+	for decoder.More() {
+		var result Result
+		if err := decoder.Decode(&result); err != nil {
+			return nil, fmt.Errorf("invalid result: %w", err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,129 @@
+package solution
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPart_Text(t *testing.T) {
+	var out bytes.Buffer
+	sol := NewWithOutput(3, Text, &out)
+	sol.Part(1, func() any { return 161 })
+	sol.PartWithNotes(2, func() (any, []string) { return nil, []string{"nothing to see"} })
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %q", out.String())
+	}
+	if !strings.HasPrefix(lines[0], "Day 3 part 1: 161 (") {
+		t.Errorf("Unexpected first line %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "Day 3 part 2: (no answer) (") {
+		t.Errorf("Unexpected second line %q", lines[1])
+	}
+	if lines[2] != "    nothing to see" {
+		t.Errorf("Unexpected note line %q", lines[2])
+	}
+}
+
+func TestPart_TSV(t *testing.T) {
+	var out bytes.Buffer
+	sol := NewWithOutput(17, TSV, &out)
+	sol.Part(1, func() any { return "4,6,3,5,6,3,5,2,1,0" })
+	sol.PartWithNotes(2, func() (any, []string) { return 117440, []string{"a", "b"} })
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || lines[0] != "day\tpart\tanswer\telapsed_ns\tnotes" {
+		t.Fatalf("Expected a header and 2 rows, got %q", out.String())
+	}
+	fields := strings.Split(lines[2], "\t")
+	if len(fields) != 5 || fields[0] != "17" || fields[1] != "2" || fields[2] != "117440" || fields[4] != "a; b" {
+		t.Errorf("Unexpected row %q", lines[2])
+	}
+}
+
+func TestPart_JSONRoundTrip(t *testing.T) {
+	var out bytes.Buffer
+	sol := NewWithOutput(24, JSON, &out)
+	sol.PartWithNotes(1, func() (any, []string) { return 2024, []string{"binary 11111101000"} })
+	sol.Part(2, func() any { return "aaa,bbb" })
+
+	results, err := Parse(&out)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := sol.Results()
+	if len(results) != len(want) {
+		t.Fatalf("Expected %d results, got %d", len(want), len(results))
+	}
+	for i := range want {
+		got := results[i]
+		if got.Day != want[i].Day || got.Part != want[i].Part || got.Answer != want[i].Answer || got.Elapsed != want[i].Elapsed || strings.Join(got.Notes, "|") != strings.Join(want[i].Notes, "|") {
+			t.Errorf("Expected %+v, got %+v", want[i], got)
+		}
+	}
+}
+
+func TestNewWithOutput_UnknownFormat(t *testing.T) {
+	var out bytes.Buffer
+	sol := NewWithOutput(1, "xml", &out)
+	sol.Part(1, func() any { return 11 })
+	if !strings.HasPrefix(out.String(), "Day 1 part 1: 11") {
+		t.Errorf("Expected text output, got %q", out.String())
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := Parse(strings.NewReader("Day 1 part 1: 11")); err == nil {
+		t.Errorf("Expected an error for text output")
+	}
+}
+
+type testSolver struct {
+	lines []string
+}
+
+func (s *testSolver) Parse(input []string) error {
+	s.lines = input
+	return nil
+}
+
+func (s *testSolver) Part1() any { return len(s.lines) }
+
+func (s *testSolver) Part2() any { return nil }
+
+func TestRegistry(t *testing.T) {
+	Register(1999, 2, func() Solver { return &testSolver{} })
+	Register(1999, 1, func() Solver { return &testSolver{} })
+
+	if _, ok := Lookup(1999, 3); ok {
+		t.Errorf("Expected day 3 to be missing")
+	}
+	solver, ok := Lookup(1999, 1)
+	if !ok {
+		t.Fatalf("Expected day 1 to be registered")
+	}
+
+	var out bytes.Buffer
+	sol := NewWithOutput(1, JSON, &out)
+	if err := sol.Solve(solver, []string{"a", "b", "c"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	results := sol.Results()
+	if len(results) != 2 || results[0].Answer != "3" || results[1].Answer != "" {
+		t.Errorf("Unexpected results %+v", results)
+	}
+
+	registered := Registered()
+	if len(registered) != 2 || registered[0] != (Puzzle{1999, 1}) || registered[1] != (Puzzle{1999, 2}) {
+		t.Errorf("Expected both days in order, got %v", registered)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected registering a day twice to panic")
+		}
+	}()
+	Register(1999, 1, func() Solver { return &testSolver{} })
+}
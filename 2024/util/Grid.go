@@ -0,0 +1,62 @@
+package util
+
+import "fmt"
+
+// Grid is a rectangular grid of values stored row by row in a single slice
+type Grid[T any] struct {
+	rows, cols int
+	cells      []T
+}
+
+// NewGrid creates a Grid of the given size filled with the zero value of T
+func NewGrid[T any](rows, cols int) *Grid[T] {
+	return &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+}
+
+// GridFrom copies a 2D slice into a Grid, every row has to be the same length
+//
+//	GridFrom(TransformStringSliceInto2DMatrix(input))
+func GridFrom[T any](values [][]T) (*Grid[T], error) {
+	if len(values) == 0 {
+		return NewGrid[T](0, 0), nil
+	}
+
+	g := NewGrid[T](len(values), len(values[0]))
+	for r, row := range values {
+		if len(row) != g.cols {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", r, len(row), g.cols)
+		}
+		copy(g.cells[r*g.cols:], row)
+	}
+	return g, nil
+}
+
+// Rows returns the number of rows
+func (g *Grid[T]) Rows() int {
+	return g.rows
+}
+
+// Cols returns the number of columns
+func (g *Grid[T]) Cols() int {
+	return g.cols
+}
+
+// InBounds checks if the cell is inside the grid
+func (g *Grid[T]) InBounds(row, col int) bool {
+	return row >= 0 && row < g.rows && col >= 0 && col < g.cols
+}
+
+// Get returns the value of a cell, the cell has to be in bounds
+func (g *Grid[T]) Get(row, col int) T {
+	return g.cells[g.Index(row, col)]
+}
+
+// Set changes the value of a cell, the cell has to be in bounds
+func (g *Grid[T]) Set(row, col int, value T) {
+	g.cells[g.Index(row, col)] = value
+}
+
+// Index returns the position of a cell in row by row order, handy for keying flat slices by cell
+func (g *Grid[T]) Index(row, col int) int {
+	return row*g.cols + col
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestGrid_FromAndGet(t *testing.T) {
+	grid, err := GridFrom([][]string{{"A", "B"}, {"C", "D"}, {"E", "F"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if grid.Rows() != 3 || grid.Cols() != 2 {
+		t.Errorf("Expected a 3x2 grid, got %dx%d", grid.Rows(), grid.Cols())
+	}
+	if grid.Get(2, 1) != "F" {
+		t.Errorf("Expected F at 2,1, got %s", grid.Get(2, 1))
+	}
+
+	grid.Set(0, 1, "Z")
+	if grid.Get(0, 1) != "Z" {
+		t.Errorf("Expected Z at 0,1, got %s", grid.Get(0, 1))
+	}
+	if grid.Index(1, 1) != 3 {
+		t.Errorf("Expected index 3 for 1,1, got %d", grid.Index(1, 1))
+	}
+}
+
+func TestGrid_InBounds(t *testing.T) {
+	grid := NewGrid[int](2, 3)
+	if !grid.InBounds(1, 2) {
+		t.Error("Expected 1,2 to be in bounds")
+	}
+	if grid.InBounds(2, 0) || grid.InBounds(0, -1) {
+		t.Error("Did not expect cells past the edge to be in bounds")
+	}
+}
+
+func TestGrid_Ragged(t *testing.T) {
+	if _, err := GridFrom([][]int{{1, 2}, {3}}); err == nil {
+		t.Error("Expected an error for a ragged grid")
+	}
+}
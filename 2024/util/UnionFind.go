@@ -0,0 +1,39 @@
+package util
+
+// UnionFind is a disjoint set over 0..n-1 with path compression and union by size
+type UnionFind struct {
+	parent []int
+	size   []int
+}
+
+// NewUnionFind creates a UnionFind where each of the n elements is in a set of its own
+func NewUnionFind(n int) *UnionFind {
+	sets := &UnionFind{parent: make([]int, n), size: make([]int, n)}
+	for i := range sets.parent {
+		sets.parent[i] = i
+		sets.size[i] = 1
+	}
+	return sets
+}
+
+// Find returns the element that represents the set x is in
+func (u *UnionFind) Find(x int) int {
+	for u.parent[x] != x {
+		u.parent[x] = u.parent[u.parent[x]]
+		x = u.parent[x]
+	}
+	return x
+}
+
+// Union merges the sets x and y are in
+func (u *UnionFind) Union(x, y int) {
+	x, y = u.Find(x), u.Find(y)
+	if x == y {
+		return
+	}
+	if u.size[x] < u.size[y] {
+		x, y = y, x
+	}
+	u.parent[y] = x
+	u.size[x] += u.size[y]
+}
@@ -0,0 +1,28 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestUnionFind(t *testing.T) {
+	sets := NewUnionFind(6)
+	for i := 0; i < 6; i++ {
+		if sets.Find(i) != i {
+			t.Errorf("Expected %d to start in a set of its own", i)
+		}
+	}
+
+	sets.Union(0, 1)
+	sets.Union(2, 3)
+	sets.Union(1, 3)
+	sets.Union(3, 0)
+
+	for _, i := range []int{1, 2, 3} {
+		if sets.Find(i) != sets.Find(0) {
+			t.Errorf("Expected %d to be in the same set as 0", i)
+		}
+	}
+	if sets.Find(4) == sets.Find(0) || sets.Find(4) == sets.Find(5) {
+		t.Error("Expected 4 to stay in a set of its own")
+	}
+}
@@ -0,0 +1,315 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// InputDirEnv overrides the directory puzzle inputs are kept in, by default the inputs directory of the module
+	InputDirEnv = "AOC_INPUT_DIR"
+	// SessionEnv holds the session cookie of an adventofcode.com login, it takes priority over the session file
+	SessionEnv = "AOC_SESSION"
+	// BaseURLEnv points the fetcher somewhere other than adventofcode.com
+	BaseURLEnv = "AOC_BASE_URL"
+	// UserAgentEnv replaces the User-Agent sent with every request, put a way to reach you in it
+	UserAgentEnv = "AOC_USER_AGENT"
+
+	DefaultBaseURL   = "https://adventofcode.com"
+	DefaultUserAgent = "advent-of-code-2024-solutions input fetcher (Go net/http, set " + UserAgentEnv + " to add contact details)"
+	// DefaultMinInterval is the least time between two requests to the site, kept across runs
+	DefaultMinInterval = 5 * time.Second
+)
+
+const (
+	// lastFetchFile in the cache directory holds the time of the last request, so separate runs share the rate limit
+	lastFetchFile = ".last-fetch"
+	// maxReason is how much of an error page ends up in the error
+	maxReason = 200
+)
+
+// Fetcher downloads puzzle inputs and keeps them on disk, so each input is only ever downloaded once
+//   - BaseURL: site to download from, inputs are at BaseURL/<year>/day/<day>/input
+//   - Session: session cookie of a logged in user, inputs are different for every user
+//   - UserAgent: sent with every request
+//   - CacheDir: directory the inputs are saved in, see InputFile for the layout
+//   - ModuleYear: year whose inputs go right in CacheDir instead of a directory named after the year
+//   - MinInterval: least time between two requests
+//   - Client: used for the requests
+type Fetcher struct {
+	BaseURL     string
+	Session     string
+	UserAgent   string
+	CacheDir    string
+	ModuleYear  int
+	MinInterval time.Duration
+	Client      *http.Client
+
+	// sleep waits out the rate limit, tests replace it so they don't have to wait
+	sleep func(time.Duration)
+}
+
+// NewFetcher creates a fetcher for this module from the environment. The session comes from AOC_SESSION or the
+// session file in the aoc config directory (~/.config/aoc/session on Linux), inputs are cached in the inputs directory
+func NewFetcher() (*Fetcher, error) {
+	cacheDir, err := InputDir()
+	if err != nil {
+		return nil, err
+	}
+	session, err := Session()
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher := &Fetcher{
+		BaseURL:     DefaultBaseURL,
+		Session:     session,
+		UserAgent:   DefaultUserAgent,
+		CacheDir:    cacheDir,
+		ModuleYear:  ModuleYear(),
+		MinInterval: DefaultMinInterval,
+		Client:      &http.Client{Timeout: 30 * time.Second},
+	}
+	if baseURL := os.Getenv(BaseURLEnv); baseURL != "" {
+		fetcher.BaseURL = baseURL
+	}
+	if userAgent := os.Getenv(UserAgentEnv); userAgent != "" {
+		fetcher.UserAgent = userAgent
+	}
+	return fetcher, nil
+}
+
+// Session finds the session cookie in AOC_SESSION or the session file, an empty session is not an error since
+// cached inputs don't need one
+func Session() (string, error) {
+	if session := os.Getenv(SessionEnv); session != "" {
+		return strings.TrimSpace(session), nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", nil
+	}
+	data, err := os.ReadFile(filepath.Join(configDir, "aoc", "session"))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to read session file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Path is where the input for a day is cached
+func (f *Fetcher) Path(year, day int) string {
+	return inputFileIn(f.CacheDir, f.ModuleYear, year, day)
+}
+
+// Input returns the input for a day as lines, reading the cache when it has the day and downloading it otherwise
+func (f *Fetcher) Input(year, day int) ([]string, error) {
+	path := f.Path(year, day)
+	if _, err := os.Stat(path); err == nil {
+		return ReadInput(path), nil
+	}
+	if err := f.Download(year, day); err != nil {
+		return nil, err
+	}
+	return ReadInput(path), nil
+}
+
+// Download fetches the input for a day and saves it to the cache, even if it is already there
+func (f *Fetcher) Download(year, day int) error {
+	if f.Session == "" {
+		return fmt.Errorf("no session to download day %d with, set %s or save it in the aoc session file", day, SessionEnv)
+	}
+	slog.Info("Downloading input", "year", year, "day", day)
+	return f.save(fmt.Sprintf("%d/day/%d/input", year, day), f.Path(year, day), day)
+}
+
+// PagePath is where the puzzle page of a day is cached, next to its input
+func (f *Fetcher) PagePath(year, day int) string {
+	return strings.TrimSuffix(f.Path(year, day), ".txt") + ".html"
+}
+
+// Page returns the puzzle page of a day, reading the cache when it has the page and downloading it otherwise.
+// Without a session the page only has part 1, refresh it with DownloadPage once part 1 is solved
+func (f *Fetcher) Page(year, day int) (string, error) {
+	path := f.PagePath(year, day)
+	if _, err := os.Stat(path); err != nil {
+		if err := f.DownloadPage(year, day); err != nil {
+			return "", err
+		}
+	}
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
+
+// DownloadPage fetches the puzzle page of a day and saves it to the cache, even if it is already there
+func (f *Fetcher) DownloadPage(year, day int) error {
+	slog.Info("Downloading puzzle page", "year", year, "day", day)
+	return f.save(fmt.Sprintf("%d/day/%d", year, day), f.PagePath(year, day), day)
+}
+
+// save downloads the path under BaseURL into a file, sending the session when there is one
+func (f *Fetcher) save(urlPath string, path string, day int) error {
+	if err := os.MkdirAll(f.CacheDir, 0o755); err != nil {
+		return err
+	}
+	f.wait()
+
+	request, err := http.NewRequest(http.MethodGet, strings.TrimRight(f.BaseURL, "/")+"/"+urlPath, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("User-Agent", f.UserAgent)
+	if f.Session != "" {
+		request.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+	}
+
+	response, err := f.client().Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to download day %d: %w", day, err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("unable to download day %d: %w", day, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		// the site explains what went wrong in the body, like the puzzle not being unlocked yet
+		reason := strings.TrimSpace(string(body))
+		if len(reason) > maxReason {
+			reason = reason[:maxReason] + "..."
+		}
+		return fmt.Errorf("unable to download day %d: %s: %s", day, response.Status, reason)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, body, 0o644)
+}
+
+// wait sleeps until MinInterval has passed since the last request, then marks now as the last request
+func (f *Fetcher) wait() {
+	marker := filepath.Join(f.CacheDir, lastFetchFile)
+	if data, err := os.ReadFile(marker); err == nil {
+		if last, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data))); err == nil {
+			if remaining := f.MinInterval - time.Since(last); remaining > 0 {
+				slog.Info("Waiting before the next request", "wait", remaining.Round(time.Millisecond))
+				f.sleepFor(remaining)
+			}
+		}
+	}
+	if err := os.WriteFile(marker, []byte(time.Now().Format(time.RFC3339Nano)), 0o644); err != nil {
+		slog.Warn("Unable to save the time of the request", "err", err)
+	}
+}
+
+func (f *Fetcher) sleepFor(d time.Duration) {
+	if f.sleep != nil {
+		f.sleep(d)
+		return
+	}
+	time.Sleep(d)
+}
+
+func (f *Fetcher) client() *http.Client {
+	if f.Client != nil {
+		return f.Client
+	}
+	return http.DefaultClient
+}
+
+// LoadInput returns the input for a day of any year, downloading it if it hasn't been yet
+func LoadInput(year, day int) ([]string, error) {
+	fetcher, err := NewFetcher()
+	if err != nil {
+		return nil, err
+	}
+	return fetcher.Input(year, day)
+}
+
+// InputDir is the directory inputs are kept in, AOC_INPUT_DIR or the inputs directory of the module
+func InputDir() (string, error) {
+	if dir := os.Getenv(InputDirEnv); dir != "" {
+		return dir, nil
+	}
+	root, err := ModuleRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, "inputs"), nil
+}
+
+// InputFile returns where the input for a day is kept, whether it is there or not. Inputs of the module's year sit
+// right in the inputs directory as dayN.txt, other years get a directory each. Year 0 is the module's year
+func InputFile(year, day int) (string, error) {
+	dir, err := InputDir()
+	if err != nil {
+		return "", err
+	}
+	return inputFileIn(dir, ModuleYear(), year, day), nil
+}
+
+func inputFileIn(dir string, moduleYear, year, day int) string {
+	if year != 0 && year != moduleYear {
+		dir = filepath.Join(dir, strconv.Itoa(year))
+	}
+	return filepath.Join(dir, fmt.Sprintf("day%d.txt", day))
+}
+
+// ModuleRoot walks up from the working directory until it finds go.mod
+func ModuleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("go.mod not found")
+		}
+		dir = parent
+	}
+}
+
+// ModulePath reads the module path out of the go.mod in root
+func ModulePath(root string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if module, found := strings.CutPrefix(strings.TrimSpace(line), "module "); found {
+			return strings.Trim(strings.TrimSpace(module), `"`), nil
+		}
+	}
+	return "", fmt.Errorf("go.mod has no module line")
+}
+
+// ModuleYear is the year this module solves, taken from the module path. 0 when it can't be found
+func ModuleYear() int {
+	root, err := ModuleRoot()
+	if err != nil {
+		return 0
+	}
+	module, err := ModulePath(root)
+	if err != nil {
+		return 0
+	}
+	year, _ := strconv.Atoi(module)
+	return year
+}
@@ -0,0 +1,181 @@
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testFetcher(t *testing.T, handler http.HandlerFunc) (*Fetcher, *[]time.Duration) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	slept := make([]time.Duration, 0)
+	return &Fetcher{
+		BaseURL:     server.URL,
+		Session:     "abc123",
+		UserAgent:   "fetch test",
+		CacheDir:    t.TempDir(),
+		ModuleYear:  2024,
+		MinInterval: time.Minute,
+		Client:      server.Client(),
+		sleep:       func(d time.Duration) { slept = append(slept, d) },
+	}, &slept
+}
+
+func TestFetcher_DownloadsOnceAndCaches(t *testing.T) {
+	var requests atomic.Int64
+	fetcher, _ := testFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/2025/day/3/input" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "abc123" {
+			t.Errorf("Expected the session cookie, got %v, %v", cookie, err)
+		}
+		if r.UserAgent() != "fetch test" {
+			t.Errorf("Unexpected User-Agent %q", r.UserAgent())
+		}
+		w.Write([]byte("987654321111111\n811111111111119\n"))
+	})
+
+	for i := 0; i < 2; i++ {
+		lines, err := fetcher.Input(2025, 3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(lines) != 2 || lines[1] != "811111111111119" {
+			t.Errorf("Unexpected input %q", lines)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected 1 request, got %d", requests.Load())
+	}
+	if _, err := os.Stat(filepath.Join(fetcher.CacheDir, "2025", "day3.txt")); err != nil {
+		t.Errorf("Expected the input to be cached under its year: %v", err)
+	}
+}
+
+func TestFetcher_Path(t *testing.T) {
+	fetcher := &Fetcher{CacheDir: "inputs", ModuleYear: 2024}
+	if path := fetcher.Path(2024, 7); path != filepath.Join("inputs", "day7.txt") {
+		t.Errorf("Expected the module's year right in the cache, got %s", path)
+	}
+	if path := fetcher.Path(2023, 7); path != filepath.Join("inputs", "2023", "day7.txt") {
+		t.Errorf("Expected other years in their own directory, got %s", path)
+	}
+}
+
+func TestFetcher_Errors(t *testing.T) {
+	fetcher, _ := testFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Please don't repeatedly request this endpoint before it unlocks!", http.StatusNotFound)
+	})
+
+	_, err := fetcher.Input(2025, 25)
+	if err == nil || !strings.Contains(err.Error(), "before it unlocks") {
+		t.Errorf("Expected the reason from the site, got %v", err)
+	}
+	if _, statErr := os.Stat(fetcher.Path(2025, 25)); statErr == nil {
+		t.Errorf("Expected nothing to be cached after an error")
+	}
+
+	fetcher.Session = ""
+	if err := fetcher.Download(2025, 1); err == nil || !strings.Contains(err.Error(), SessionEnv) {
+		t.Errorf("Expected an error about the missing session, got %v", err)
+	}
+}
+
+func TestFetcher_RateLimit(t *testing.T) {
+	fetcher, slept := testFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("input\n"))
+	})
+
+	for day := 1; day <= 2; day++ {
+		if err := fetcher.Download(2025, day); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if len(*slept) != 1 || (*slept)[0] <= 0 || (*slept)[0] > fetcher.MinInterval {
+		t.Errorf("Expected a single wait of at most %s before the second request, got %v", fetcher.MinInterval, *slept)
+	}
+}
+
+func TestSession(t *testing.T) {
+	config := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	t.Setenv("HOME", config)
+	t.Setenv(SessionEnv, "")
+
+	if session, err := Session(); err != nil || session != "" {
+		t.Errorf("Expected no session, got %q, %v", session, err)
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		t.Skipf("No config directory: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "aoc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "aoc", "session"), []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if session, err := Session(); err != nil || session != "from-file" {
+		t.Errorf("Expected the session from the file, got %q, %v", session, err)
+	}
+
+	t.Setenv(SessionEnv, "from-env")
+	if session, err := Session(); err != nil || session != "from-env" {
+		t.Errorf("Expected the session from the environment, got %q, %v", session, err)
+	}
+}
+
+func TestNewFetcher_Environment(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(InputDirEnv, dir)
+	t.Setenv(SessionEnv, "env-session")
+	t.Setenv(BaseURLEnv, "http://localhost:1234")
+	t.Setenv(UserAgentEnv, "someone@example.com")
+
+	fetcher, err := NewFetcher()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fetcher.CacheDir != dir || fetcher.Session != "env-session" || fetcher.BaseURL != "http://localhost:1234" || fetcher.UserAgent != "someone@example.com" {
+		t.Errorf("Expected the fetcher to be set up from the environment, got %+v", fetcher)
+	}
+	if fetcher.ModuleYear != 2024 {
+		t.Errorf("Expected the module year 2024, got %d", fetcher.ModuleYear)
+	}
+}
+
+func TestFetcher_Page(t *testing.T) {
+	var requests atomic.Int64
+	fetcher, _ := testFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/2024/day/5" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte("<article>page</article>"))
+	})
+	fetcher.Session = ""
+
+	for i := 0; i < 2; i++ {
+		page, err := fetcher.Page(2024, 5)
+		if err != nil || page != "<article>page</article>" {
+			t.Errorf("Unexpected page %q, %v", page, err)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected the page to be downloaded once, got %d requests", requests.Load())
+	}
+	if path := fetcher.PagePath(2024, 5); filepath.Base(path) != "day5.html" {
+		t.Errorf("Unexpected page path %s", path)
+	}
+}
@@ -0,0 +1,155 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Attempt is an answer that was submitted
+//   - Part: part the answer was for
+//   - Answer: the answer as it was sent
+//   - Verdict: what the site said about it
+//   - Time: when it was sent
+type Attempt struct {
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+}
+
+// History is every answer submitted for a day. It is kept next to the input of the day, so wrong answers and the
+// bounds learned from "too high" and "too low" survive between runs
+type History struct {
+	path     string
+	Attempts []Attempt `json:"attempts"`
+}
+
+// Bounds is the range a numeric answer has to be in, learned from earlier answers. Low and High are exclusive and
+// only set when HasLow and HasHigh are
+type Bounds struct {
+	Low, High       int
+	HasLow, HasHigh bool
+}
+
+// Contains is true when value is inside the bounds
+func (b Bounds) Contains(value int) bool {
+	return (!b.HasLow || value > b.Low) && (!b.HasHigh || value < b.High)
+}
+
+func (b Bounds) String() string {
+	parts := make([]string, 0, 2)
+	if b.HasLow {
+		parts = append(parts, fmt.Sprintf("> %d", b.Low))
+	}
+	if b.HasHigh {
+		parts = append(parts, fmt.Sprintf("< %d", b.High))
+	}
+	if len(parts) == 0 {
+		return "unbounded"
+	}
+	return strings.Join(parts, " and ")
+}
+
+// HistoryPath is where the history of a day is kept, next to its cached input
+func (f *Fetcher) HistoryPath(year, day int) string {
+	return strings.TrimSuffix(f.Path(year, day), ".txt") + ".answers.json"
+}
+
+// LoadHistory reads a history file, a missing file is an empty history
+func LoadHistory(path string) (*History, error) {
+	history := &History{path: path, Attempts: make([]Attempt, 0)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("invalid history %s: %w", path, err)
+	}
+	return history, nil
+}
+
+// Save writes the history back to the file it was loaded from
+func (h *History) Save() error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(h.path, append(data, '\n'), 0o644)
+}
+
+// Record adds a submitted answer to the history. Rate limited answers were never looked at, so they aren't kept
+func (h *History) Record(part int, answer string, verdict Verdict) {
+	if verdict == RateLimited {
+		return
+	}
+	h.Attempts = append(h.Attempts, Attempt{Part: part, Answer: answer, Verdict: verdict, Time: time.Now()})
+}
+
+// Solved returns the correct answer to a part, if it has been submitted
+func (h *History) Solved(part int) (string, bool) {
+	for _, attempt := range h.Attempts {
+		if attempt.Part == part && attempt.Verdict == Correct {
+			return attempt.Answer, true
+		}
+	}
+	return "", false
+}
+
+// Bounds returns the range the answer to a part has to be in, from the answers that were too high or too low
+func (h *History) Bounds(part int) Bounds {
+	var bounds Bounds
+	for _, attempt := range h.Attempts {
+		if attempt.Part != part {
+			continue
+		}
+		value, err := strconv.Atoi(attempt.Answer)
+		if err != nil {
+			continue
+		}
+		switch attempt.Verdict {
+		case TooLow:
+			if !bounds.HasLow || value > bounds.Low {
+				bounds.Low, bounds.HasLow = value, true
+			}
+		case TooHigh:
+			if !bounds.HasHigh || value < bounds.High {
+				bounds.High, bounds.HasHigh = value, true
+			}
+		}
+	}
+	return bounds
+}
+
+// Check returns an error when an answer is known to be wrong without asking the site: the part is already solved,
+// the same answer was wrong before, or it is outside the bounds
+func (h *History) Check(part int, answer string) error {
+	if solved, ok := h.Solved(part); ok {
+		return fmt.Errorf("part %d is already solved, the answer was %s", part, solved)
+	}
+	for _, attempt := range h.Attempts {
+		if attempt.Part == part && attempt.Answer == answer && attempt.Verdict.IsWrong() {
+			return fmt.Errorf("%s was already submitted on %s and was %s", answer, attempt.Time.Format(time.DateTime), attempt.Verdict)
+		}
+	}
+
+	value, err := strconv.Atoi(answer)
+	if err != nil {
+		return nil
+	}
+	if bounds := h.Bounds(part); !bounds.Contains(value) {
+		return fmt.Errorf("%s is outside of what earlier answers allow, the answer is %s", answer, bounds)
+	}
+	return nil
+}
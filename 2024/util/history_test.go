@@ -0,0 +1,86 @@
+package util
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHistory_Check(t *testing.T) {
+	history, err := LoadHistory(filepath.Join(t.TempDir(), "day7.answers.json"))
+	if err != nil {
+		t.Fatalf("Unexpected error for a missing history: %v", err)
+	}
+	if err := history.Check(1, "100"); err != nil {
+		t.Errorf("Expected an empty history to allow anything, got %v", err)
+	}
+
+	history.Record(1, "100", TooLow)
+	history.Record(1, "500", TooHigh)
+	history.Record(1, "200", TooLow)
+	history.Record(1, "abc", Wrong)
+	history.Record(1, "300", RateLimited)
+	history.Record(2, "50", TooLow)
+
+	tests := map[string]string{
+		"250": "",
+		"300": "",
+		"200": "already submitted",
+		"150": "outside",
+		"500": "already submitted",
+		"501": "outside",
+		"abc": "already submitted",
+		"xyz": "",
+	}
+	for answer, want := range tests {
+		err := history.Check(1, answer)
+		if want == "" && err != nil {
+			t.Errorf("Expected %s to be allowed, got %v", answer, err)
+		}
+		if want != "" && (err == nil || !strings.Contains(err.Error(), want)) {
+			t.Errorf("Expected %s to be blocked with %q, got %v", answer, want, err)
+		}
+	}
+
+	if bounds := history.Bounds(1); bounds.String() != "> 200 and < 500" {
+		t.Errorf("Unexpected bounds %s", bounds)
+	}
+	if bounds := history.Bounds(2); bounds.String() != "> 50" || !bounds.Contains(51) || bounds.Contains(50) {
+		t.Errorf("Unexpected bounds %s for part 2", bounds)
+	}
+
+	history.Record(1, "250", Correct)
+	if err := history.Check(1, "260"); err == nil || !strings.Contains(err.Error(), "already solved") {
+		t.Errorf("Expected a solved part to be blocked, got %v", err)
+	}
+}
+
+func TestHistory_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "2025", "day1.answers.json")
+	history, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history.Record(1, "42", TooHigh)
+	if err := history.Save(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loaded, err := LoadHistory(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(loaded.Attempts) != 1 || loaded.Attempts[0].Answer != "42" || loaded.Attempts[0].Verdict != TooHigh {
+		t.Errorf("Unexpected history %+v", loaded.Attempts)
+	}
+	if err := loaded.Check(1, "42"); err == nil {
+		t.Errorf("Expected the loaded history to block 42")
+	}
+}
+
+func TestFetcher_HistoryPath(t *testing.T) {
+	fetcher := &Fetcher{CacheDir: "inputs", ModuleYear: 2024}
+	if path := fetcher.HistoryPath(2024, 7); path != filepath.Join("inputs", "day7.answers.json") {
+		t.Errorf("Unexpected history path %s", path)
+	}
+}
@@ -0,0 +1,220 @@
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var intPattern = regexp.MustCompile(`-?\d+`)
+
+// Ints pulls every integer out of a line, a minus sign right before the digits makes it negative
+//
+//	"p=0,4 v=3,-3" -> [0 4 3 -3]
+func Ints(line string) ([]int, error) {
+	matches := intPattern.FindAllString(line, -1)
+	toReturn := make([]int, 0, len(matches))
+	for _, match := range matches {
+		value, err := strconv.Atoi(match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q in %q: %w", match, line, err)
+		}
+		toReturn = append(toReturn, value)
+	}
+	return toReturn, nil
+}
+
+// Sections splits the input on blank lines when it is made of exactly n sections, like the rules and the
+// updates of Day 5. Blank lines at the start or the end don't count as separators
+func Sections(lines []string, n int) ([][]string, error) {
+	sections := Blocks(lines)
+	if len(sections) != n {
+		return nil, fmt.Errorf("expected %d sections separated by blank lines, found %d", n, len(sections))
+	}
+	return sections, nil
+}
+
+// Blocks splits the input on blank lines into however many records there are, like the locks and keys of
+// Day 25. Runs of blank lines are treated as a single separator, so no block is ever empty
+func Blocks(lines []string) [][]string {
+	blocks := make([][]string, 0)
+	block := make([]string, 0)
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = make([]string, 0)
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// DigitGrid turns lines of single digits into a grid of ints
+//
+//	[012 345] -> [[0 1 2] [3 4 5]]
+func DigitGrid(lines []string) ([][]int, error) {
+	grid := make([][]int, 0, len(lines))
+	for r, line := range lines {
+		row := make([]int, 0, len(line))
+		for c, char := range line {
+			if char < '0' || char > '9' {
+				return nil, fmt.Errorf("%q at %d,%d is not a digit", char, r, c)
+			}
+			row = append(row, int(char-'0'))
+		}
+		grid = append(grid, row)
+	}
+	return grid, nil
+}
+
+// CharGrid turns lines into a grid of characters like TransformStringSliceInto2DMatrix, for solvers that need the
+// grid to be rectangular. It fails if there are no rows or a row is not as long as the first one
+func CharGrid(lines []string) ([][]string, error) {
+	if len(lines) == 0 || lines[0] == "" {
+		return nil, fmt.Errorf("the grid is empty")
+	}
+	grid := TransformStringSliceInto2DMatrix(lines)
+	for r, row := range grid {
+		if len(row) != len(grid[0]) {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", r, len(row), len(grid[0]))
+		}
+	}
+	return grid, nil
+}
+
+// KeyValue splits a line on the first sep and trims the spaces around both sides
+//
+//	KeyValue("x00: 1", ":") -> "x00", "1"
+func KeyValue(line string, sep string) (string, string, error) {
+	key, value, found := strings.Cut(line, sep)
+	if !found {
+		return "", "", fmt.Errorf("no %q in %q", sep, line)
+	}
+	return strings.TrimSpace(key), strings.TrimSpace(value), nil
+}
+
+// LineDecoder fills a struct from lines matching a regular expression. Every field with an `aoc` tag is set
+// from the named group with the same name, so
+//
+//	type wire struct {
+//		Name  string `aoc:"name"`
+//		Value int    `aoc:"value"`
+//	}
+//
+// decodes "x00: 1" with the pattern `(?P<name>\w+): (?P<value>\d+)`. Tagged fields have to be exported and can be
+// strings, integers or []int, which takes every integer in the group like Ints
+type LineDecoder[T any] struct {
+	pattern *regexp.Regexp
+	// fields maps a field index of T to the index of its group in pattern
+	fields map[int]int
+}
+
+// NewLineDecoder compiles the pattern and checks that every tagged field of T has a group it can be decoded from
+func NewLineDecoder[T any](pattern string) (*LineDecoder[T], error) {
+	reg, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	structType := reflect.TypeFor[T]()
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("lines can only be decoded into structs, not %s", structType)
+	}
+
+	decoder := &LineDecoder[T]{pattern: reg, fields: make(map[int]int)}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, ok := field.Tag.Lookup("aoc")
+		if !ok {
+			continue
+		}
+		if !field.IsExported() {
+			return nil, fmt.Errorf("field %s is tagged but not exported", field.Name)
+		}
+		if !decodable(field.Type) {
+			return nil, fmt.Errorf("field %s has type %s, which can't be decoded", field.Name, field.Type)
+		}
+		group := reg.SubexpIndex(name)
+		if group < 0 {
+			return nil, fmt.Errorf("field %s wants group %q, which is not in the pattern", field.Name, name)
+		}
+		decoder.fields[i] = group
+	}
+	return decoder, nil
+}
+
+// Decode decodes a single line
+func (d *LineDecoder[T]) Decode(line string) (T, error) {
+	var toReturn T
+	groups := d.pattern.FindStringSubmatch(line)
+	if groups == nil {
+		return toReturn, fmt.Errorf("%q does not match %s", line, d.pattern)
+	}
+
+	value := reflect.ValueOf(&toReturn).Elem()
+	for field, group := range d.fields {
+		if err := setField(value.Field(field), groups[group]); err != nil {
+			return toReturn, fmt.Errorf("field %s of %q: %w", value.Type().Field(field).Name, line, err)
+		}
+	}
+	return toReturn, nil
+}
+
+// DecodeAll decodes every line, the error says which line couldn't be decoded
+func (d *LineDecoder[T]) DecodeAll(lines []string) ([]T, error) {
+	toReturn := make([]T, 0, len(lines))
+	for i, line := range lines {
+		decoded, err := d.Decode(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		toReturn = append(toReturn, decoded)
+	}
+	return toReturn, nil
+}
+
+func decodable(fieldType reflect.Type) bool {
+	switch fieldType.Kind() {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Slice:
+		return fieldType.Elem().Kind() == reflect.Int
+	default:
+		return false
+	}
+}
+
+func setField(field reflect.Value, text string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(text, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(text, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(value)
+	case reflect.Slice:
+		values, err := Ints(text)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(values).Convert(field.Type()))
+	}
+	return nil
+}
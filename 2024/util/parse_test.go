@@ -0,0 +1,184 @@
+package util
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestInts(t *testing.T) {
+	tests := map[string][]int{
+		"p=0,4 v=3,-3":               {0, 4, 3, -3},
+		"Button A: X+94, Y+34":       {94, 34},
+		"Program: 0,1,5,4,3,0":       {0, 1, 5, 4, 3, 0},
+		"no numbers here":            {},
+		"Register A: 2024":           {2024},
+		"190: 10 19":                 {190, 10, 19},
+		"x-1":                        {-1},
+		"Prize: X=8400, Y=5400 --12": {8400, 5400, -12},
+	}
+	for line, want := range tests {
+		got, err := Ints(line)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", line, err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v for %q, got %v", want, line, got)
+		}
+	}
+
+	if _, err := Ints("99999999999999999999"); err == nil {
+		t.Errorf("Expected an error for an integer that overflows")
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	lines := []string{"", "#####", ".....", "", "", ".....", "#####", "  ", "x"}
+	blocks := Blocks(lines)
+	if len(blocks) != 3 {
+		t.Fatalf("Expected 3 blocks, got %v", blocks)
+	}
+	if !slices.Equal(blocks[0], []string{"#####", "....."}) || !slices.Equal(blocks[2], []string{"x"}) {
+		t.Errorf("Unexpected blocks %v", blocks)
+	}
+
+	if len(Blocks(nil)) != 0 {
+		t.Errorf("Expected no blocks for no lines")
+	}
+}
+
+func TestSections(t *testing.T) {
+	lines := []string{"47|53", "97|13", "", "75,47,61", ""}
+	sections, err := Sections(lines, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sections[0]) != 2 || len(sections[1]) != 1 {
+		t.Errorf("Unexpected sections %v", sections)
+	}
+
+	if _, err := Sections(lines, 3); err == nil {
+		t.Errorf("Expected an error when the number of sections is wrong")
+	}
+}
+
+func TestDigitGrid(t *testing.T) {
+	grid, err := DigitGrid([]string{"0123", "9876"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(grid[0], []int{0, 1, 2, 3}) || !slices.Equal(grid[1], []int{9, 8, 7, 6}) {
+		t.Errorf("Unexpected grid %v", grid)
+	}
+
+	_, err = DigitGrid([]string{"0123", "98.6"})
+	if err == nil || !strings.Contains(err.Error(), "1,2") {
+		t.Errorf("Expected an error pointing at 1,2, got %v", err)
+	}
+}
+
+func TestCharGrid(t *testing.T) {
+	grid, err := CharGrid([]string{"#S.", "..E"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(grid) != 2 || grid[0][1] != "S" || grid[1][2] != "E" {
+		t.Errorf("Unexpected grid %v", grid)
+	}
+
+	if _, err := CharGrid([]string{"#S.", ".E"}); err == nil || !strings.Contains(err.Error(), "row 1") {
+		t.Errorf("Expected an error for row 1, got %v", err)
+	}
+	if _, err := CharGrid(nil); err == nil {
+		t.Errorf("Expected an error for an empty grid")
+	}
+}
+
+func TestKeyValue(t *testing.T) {
+	key, value, err := KeyValue("x00: 1", ":")
+	if err != nil || key != "x00" || value != "1" {
+		t.Errorf("Expected x00 and 1, got %q, %q, %v", key, value, err)
+	}
+
+	key, value, err = KeyValue("a -> b -> c", "->")
+	if err != nil || key != "a" || value != "b -> c" {
+		t.Errorf("Expected to split on the first separator, got %q, %q, %v", key, value, err)
+	}
+
+	if _, _, err := KeyValue("x00 1", ":"); err == nil {
+		t.Errorf("Expected an error when the separator is missing")
+	}
+}
+
+type testGate struct {
+	One       string `aoc:"one"`
+	Two       string `aoc:"two"`
+	Operation string `aoc:"op"`
+	Output    string `aoc:"out"`
+	Skipped   int
+}
+
+type testEquation struct {
+	Target int64 `aoc:"target"`
+	Values []int `aoc:"values"`
+	Small  uint8 `aoc:"small"`
+}
+
+func TestLineDecoder(t *testing.T) {
+	gates, err := NewLineDecoder[testGate](`(?P<one>\w+) (?P<op>AND|OR|XOR) (?P<two>\w+) -> (?P<out>\w+)`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded, err := gates.DecodeAll([]string{"x00 AND y00 -> z00", "ntg XOR fgs -> mjb"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []testGate{{"x00", "y00", "AND", "z00", 0}, {"ntg", "fgs", "XOR", "mjb", 0}}
+	if !slices.Equal(decoded, want) {
+		t.Errorf("Expected %v, got %v", want, decoded)
+	}
+
+	_, err = gates.DecodeAll([]string{"x00 AND y00 -> z00", "x00 NAND y00 -> z00"})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error for line 2, got %v", err)
+	}
+
+	equations, err := NewLineDecoder[testEquation](`(?P<target>\d+) \((?P<small>\d+)\): (?P<values>.*)`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	equation, err := equations.Decode("3267 (7): 81 40 27")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if equation.Target != 3267 || equation.Small != 7 || !slices.Equal(equation.Values, []int{81, 40, 27}) {
+		t.Errorf("Unexpected equation %+v", equation)
+	}
+	if _, err := equations.Decode("3267 (300): 81"); err == nil {
+		t.Errorf("Expected an error when the value doesn't fit the field")
+	}
+}
+
+func TestNewLineDecoder_Invalid(t *testing.T) {
+	if _, err := NewLineDecoder[testGate](`(?P<one>\w+)`); err == nil {
+		t.Errorf("Expected an error for a field without a group")
+	}
+	if _, err := NewLineDecoder[testGate](`(`); err == nil {
+		t.Errorf("Expected an error for an invalid pattern")
+	}
+	if _, err := NewLineDecoder[int](`\d+`); err == nil {
+		t.Errorf("Expected an error for a type that is not a struct")
+	}
+	type unexported struct {
+		value int `aoc:"value"`
+	}
+	if _, err := NewLineDecoder[unexported](`(?P<value>\d+)`); err == nil {
+		t.Errorf("Expected an error for an unexported field")
+	}
+	type unsupported struct {
+		Value float64 `aoc:"value"`
+	}
+	if _, err := NewLineDecoder[unsupported](`(?P<value>\d+)`); err == nil {
+		t.Errorf("Expected an error for a field type that can't be decoded")
+	}
+}
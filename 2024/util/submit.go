@@ -0,0 +1,121 @@
+package util
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict is what the site said about a submitted answer
+type Verdict string
+
+const (
+	Correct     Verdict = "correct"
+	Wrong       Verdict = "wrong"
+	TooHigh     Verdict = "too high"
+	TooLow      Verdict = "too low"
+	RateLimited Verdict = "rate limited"
+	// WrongLevel means the part is already solved or part 1 isn't solved yet
+	WrongLevel Verdict = "wrong level"
+	Unknown    Verdict = "unknown"
+)
+
+// IsWrong is true for every verdict that rules the answer out
+func (v Verdict) IsWrong() bool {
+	return v == Wrong || v == TooHigh || v == TooLow
+}
+
+// Submission is the reply to a submitted answer
+//   - Verdict: what the reply means
+//   - Message: the text of the reply without the page around it
+//   - Wait: how long the site wants you to wait before the next answer, when it says so
+type Submission struct {
+	Verdict Verdict
+	Message string
+	Wait    time.Duration
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	waitPattern    = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+)
+
+// Submit posts the answer to a part and reads the verdict out of the reply. Submissions share the rate limit of
+// downloads
+func (f *Fetcher) Submit(year, day, part int, answer string) (Submission, error) {
+	if f.Session == "" {
+		return Submission{}, fmt.Errorf("no session to submit day %d with, set %s or save it in the aoc session file", day, SessionEnv)
+	}
+	if err := os.MkdirAll(f.CacheDir, 0o755); err != nil {
+		return Submission{}, err
+	}
+	f.wait()
+
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	endpoint := fmt.Sprintf("%s/%d/day/%d/answer", strings.TrimRight(f.BaseURL, "/"), year, day)
+	request, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Submission{}, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("User-Agent", f.UserAgent)
+	request.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+
+	slog.Info("Submitting answer", "year", year, "day", day, "part", part, "answer", answer)
+	response, err := f.client().Do(request)
+	if err != nil {
+		return Submission{}, fmt.Errorf("unable to submit day %d: %w", day, err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return Submission{}, fmt.Errorf("unable to submit day %d: %w", day, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return Submission{}, fmt.Errorf("unable to submit day %d: %s", day, response.Status)
+	}
+	return ParseSubmission(string(body)), nil
+}
+
+// ParseSubmission reads the verdict out of the page the site replies to an answer with
+func ParseSubmission(page string) Submission {
+	message := page
+	if article := articlePattern.FindStringSubmatch(page); article != nil {
+		message = article[1]
+	}
+	message = html.UnescapeString(tagPattern.ReplaceAllString(message, ""))
+	message = strings.Join(strings.Fields(message), " ")
+
+	submission := Submission{Verdict: Unknown, Message: message}
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		submission.Verdict = Correct
+	case strings.Contains(message, "You gave an answer too recently"):
+		submission.Verdict = RateLimited
+		if wait := waitPattern.FindStringSubmatch(message); wait != nil {
+			minutes, _ := strconv.Atoi(wait[1])
+			seconds, _ := strconv.Atoi(wait[2])
+			submission.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+		}
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		submission.Verdict = WrongLevel
+	case strings.Contains(message, "That's not the right answer"):
+		submission.Verdict = Wrong
+		if strings.Contains(message, "your answer is too high") {
+			submission.Verdict = TooHigh
+		} else if strings.Contains(message, "your answer is too low") {
+			submission.Verdict = TooLow
+		}
+	}
+	return submission
+}
@@ -0,0 +1,67 @@
+package util
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func page(article string) string {
+	return `<!DOCTYPE html><html><head><title>Day 7 - Advent of Code 2024</title></head><body><main><article><p>` + article + `</p></article></main></body></html>`
+}
+
+func TestParseSubmission(t *testing.T) {
+	tests := []struct {
+		page    string
+		verdict Verdict
+		wait    time.Duration
+	}{
+		{page(`That's the right answer!  You are <span class="day-success">one gold star</span> closer to finding the Chief Historian. <a href="/2024/day/7#part2">[Continue to Part Two]</a>`), Correct, 0},
+		{page(`That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2024/about">about page</a>. Please wait one minute before trying again. <a href="/2024/day/7">[Return to Day 7]</a>`), TooHigh, 0},
+		{page(`That's not the right answer; your answer is too low.  Please wait one minute before trying again.`), TooLow, 0},
+		{page(`That's not the right answer.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again.`), Wrong, 0},
+		{page(`You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 34s left to wait. <a href="/2024/day/7">[Return to Day 7]</a>`), RateLimited, 34 * time.Second},
+		{page(`You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 2s left to wait.`), RateLimited, 4*time.Minute + 2*time.Second},
+		{page(`You don't seem to be solving the right level.  Did you already complete it? <a href="/2024/day/7">[Return to Day 7]</a>`), WrongLevel, 0},
+		{`<html>Something else entirely</html>`, Unknown, 0},
+	}
+	for _, test := range tests {
+		submission := ParseSubmission(test.page)
+		if submission.Verdict != test.verdict || submission.Wait != test.wait {
+			t.Errorf("Expected %s and a wait of %s, got %s and %s for %q", test.verdict, test.wait, submission.Verdict, submission.Wait, submission.Message)
+		}
+	}
+
+	message := ParseSubmission(tests[0].page).Message
+	if message != "That's the right answer! You are one gold star closer to finding the Chief Historian. [Continue to Part Two]" {
+		t.Errorf("Expected the message without tags, got %q", message)
+	}
+}
+
+func TestFetcher_Submit(t *testing.T) {
+	fetcher, _ := testFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/7/answer" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "abc123" {
+			t.Errorf("Expected the session cookie, got %v, %v", cookie, err)
+		}
+		if r.FormValue("level") != "2" || r.FormValue("answer") != "11387" {
+			t.Errorf("Unexpected form %v", r.Form)
+		}
+		w.Write([]byte(page(`That's not the right answer; your answer is too low.`)))
+	})
+
+	submission, err := fetcher.Submit(2024, 7, 2, "11387")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if submission.Verdict != TooLow {
+		t.Errorf("Expected too low, got %s", submission.Verdict)
+	}
+
+	fetcher.Session = ""
+	if _, err := fetcher.Submit(2024, 7, 2, "11387"); err == nil {
+		t.Errorf("Expected an error without a session")
+	}
+}
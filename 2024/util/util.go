@@ -3,7 +3,8 @@ package util
 import (
 	"bufio"
 	"flag"
-	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
@@ -11,15 +12,20 @@ import (
 
 // ReadInput takes a filename and reads in the contents into a string slice for each line of the file
 func ReadInput(filename string) []string {
-	fmt.Println("Reading file ", filename)
+	slog.Debug("Reading file", "file", filename)
 	data, _ := os.Open(filename)
-	scanner := bufio.NewScanner(data)
+	defer data.Close()
+	return ReadLines(data)
+}
+
+// ReadLines splits what the reader has into lines the same way ReadInput does, so a test can hand a parser a string
+func ReadLines(r io.Reader) []string {
+	scanner := bufio.NewScanner(r)
 	scanner.Split(bufio.ScanLines)
 	var fileLines []string
 	for scanner.Scan() {
 		fileLines = append(fileLines, scanner.Text())
 	}
-	data.Close()
 	return fileLines
 }
 
@@ -29,7 +35,7 @@ func Parameter() string {
 	flag.Parse()
 
 	if *filename == "" {
-		fmt.Println("Please provide a file!")
+		slog.Error("Please provide a file with -file")
 	}
 	return *filename
 }
@@ -50,7 +56,7 @@ func TransformStringSliceInto2DMatrix(input []string) [][]string {
 func ParseInt(s string) int {
 	val, err := strconv.Atoi(s)
 	if err != nil {
-		fmt.Println("Error trying to parse int from string: ", err)
+		slog.Error("Error trying to parse int from string", "err", err)
 		os.Exit(1)
 	}
 	return val
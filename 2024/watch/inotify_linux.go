@@ -0,0 +1,88 @@
+package watch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// events are the inotify events that mean a file was saved, created, deleted or renamed
+const events = syscall.IN_CLOSE_WRITE | syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO
+
+// inotify is a watch on every directory in dirs
+//   - fd, file: the inotify instance, non-blocking so closing the file stops the read. The descriptor is kept
+//     since asking the file for it would switch it back to blocking
+//   - dirs: the directories and the names wanted in them, new subdirectories of a fully watched one are added
+//   - watches: the directory of every watch descriptor
+type inotify struct {
+	fd      int
+	file    *os.File
+	dirs    map[string]map[string]bool
+	watches map[int32]string
+}
+
+// startInotify adds a watch for every directory and sends the wanted paths from the events until done is closed
+func startInotify(dirs map[string]map[string]bool, raw chan<- string, done <-chan struct{}) (func() error, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start inotify: %w", err)
+	}
+	n := &inotify{fd: fd, file: os.NewFile(uintptr(fd), "inotify"), dirs: dirs, watches: make(map[int32]string)}
+	for dir := range dirs {
+		if err := n.add(dir); err != nil {
+			n.file.Close()
+			return nil, err
+		}
+	}
+	go n.read(raw, done)
+	return n.file.Close, nil
+}
+
+func (n *inotify) add(dir string) error {
+	wd, err := syscall.InotifyAddWatch(n.fd, dir, events)
+	if err != nil {
+		return fmt.Errorf("unable to watch %s: %w", dir, err)
+	}
+	n.watches[int32(wd)] = dir
+	return nil
+}
+
+// read decodes the events, each is a header followed by the name of the entry padded with NULs
+func (n *inotify) read(raw chan<- string, done <-chan struct{}) {
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		count, err := n.file.Read(buf)
+		if err != nil {
+			return
+		}
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= count; {
+			wd := int32(binary.NativeEndian.Uint32(buf[offset:]))
+			mask := binary.NativeEndian.Uint32(buf[offset+4:])
+			length := int(binary.NativeEndian.Uint32(buf[offset+12:]))
+			start := offset + syscall.SizeofInotifyEvent
+			name := strings.TrimRight(string(buf[start:min(start+length, count)]), "\x00")
+			offset = start + length
+
+			dir, ok := n.watches[wd]
+			if !ok || name == "" || !wanted(n.dirs, dir, name) {
+				continue
+			}
+			path := filepath.Join(dir, name)
+			if mask&syscall.IN_ISDIR != 0 && mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0 && n.dirs[dir] == nil {
+				n.dirs[path] = nil
+				if err := n.add(path); err != nil {
+					slog.Warn("Unable to watch new directory, changes in it will be missed", "dir", path, "err", err)
+				}
+			}
+			select {
+			case raw <- path:
+			case <-done:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package watch
+
+import "fmt"
+
+// startInotify always fails away from Linux, so New falls back to polling
+func startInotify(dirs map[string]map[string]bool, raw chan<- string, done <-chan struct{}) (func() error, error) {
+	return nil, fmt.Errorf("inotify is only available on Linux")
+}
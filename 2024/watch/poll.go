@@ -0,0 +1,110 @@
+package watch
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileState is what polling compares to tell a file changed
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// startPolling lists the watched directories every interval and sends the paths that were added, removed or
+// modified since the last listing. Directories created since then are watched from that listing on
+func startPolling(dirs map[string]map[string]bool, interval time.Duration, raw chan<- string, done <-chan struct{}) func() error {
+	seen := snapshot(dirs)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			discover(dirs)
+			current := snapshot(dirs)
+			for path, state := range current {
+				if before, ok := seen[path]; ok && before == state {
+					continue
+				}
+				select {
+				case raw <- path:
+				case <-done:
+					return
+				}
+			}
+			for path := range seen {
+				if _, ok := current[path]; ok {
+					continue
+				}
+				select {
+				case raw <- path:
+				case <-done:
+					return
+				}
+			}
+			seen = current
+		}
+	}()
+	return func() error { return nil }
+}
+
+// snapshot records the state of every wanted entry of the directories, a directory that can't be read counts as
+// empty. Watched subdirectories are left out, their own entries say what changed in them
+func snapshot(dirs map[string]map[string]bool) map[string]fileState {
+	states := make(map[string]fileState)
+	for dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			if _, watched := dirs[path]; watched || !wanted(dirs, dir, entry.Name()) {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			states[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return states
+}
+
+// discover adds the subdirectories created in the fully watched directories, with everything below them, so the files
+// in them are listed like those that were there from the start
+func discover(dirs map[string]map[string]bool) {
+	for dir, names := range dirs {
+		if names != nil {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			if _, watched := dirs[path]; watched || !entry.IsDir() || ignored(entry.Name()) {
+				continue
+			}
+			filepath.WalkDir(path, func(sub string, d fs.DirEntry, err error) error {
+				if err != nil || !d.IsDir() {
+					return nil
+				}
+				if sub != path && ignored(d.Name()) {
+					return filepath.SkipDir
+				}
+				dirs[sub] = nil
+				return nil
+			})
+		}
+	}
+}
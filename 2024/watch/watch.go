@@ -0,0 +1,185 @@
+// Package watch tells when files change, with inotify on Linux and by polling their modification times everywhere
+// else. Changes come in batches, so an editor that writes a file in several steps only triggers one rerun
+package watch
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Inotify and Polling are the ways a Watcher can notice changes
+	Inotify = "inotify"
+	Polling = "polling"
+
+	// settle is how long a batch waits for more changes after the last one before it is sent
+	settle = 100 * time.Millisecond
+)
+
+// Watcher sends the paths that changed in the watched directories and files
+//   - Backend: how changes are noticed, Inotify or Polling
+type Watcher struct {
+	Backend string
+
+	changes chan []string
+	raw     chan string
+	done    chan struct{}
+	stop    func() error
+	once    sync.Once
+}
+
+// New watches the paths with inotify, falling back to polling every interval where inotify isn't available.
+// Directories are watched with everything below them, files are watched by name so editors that save by
+// replacing the file are still noticed, and a file that doesn't exist yet is picked up once it is created
+func New(paths []string, interval time.Duration) (*Watcher, error) {
+	dirs, err := targets(paths)
+	if err != nil {
+		return nil, err
+	}
+	w := newWatcher(Inotify)
+	if w.stop, err = startInotify(dirs, w.raw, w.done); err != nil {
+		w.Backend = Polling
+		w.stop = startPolling(dirs, interval, w.raw, w.done)
+	}
+	go w.batch()
+	return w, nil
+}
+
+// NewPolling watches the paths like New, but always by polling every interval
+func NewPolling(paths []string, interval time.Duration) (*Watcher, error) {
+	dirs, err := targets(paths)
+	if err != nil {
+		return nil, err
+	}
+	w := newWatcher(Polling)
+	w.stop = startPolling(dirs, interval, w.raw, w.done)
+	go w.batch()
+	return w, nil
+}
+
+func newWatcher(backend string) *Watcher {
+	return &Watcher{
+		Backend: backend,
+		changes: make(chan []string),
+		raw:     make(chan string),
+		done:    make(chan struct{}),
+	}
+}
+
+// Changes receives the sorted paths that changed since the last batch, it is closed when the Watcher is
+func (w *Watcher) Changes() <-chan []string {
+	return w.changes
+}
+
+// Close stops watching
+func (w *Watcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		close(w.done)
+		err = w.stop()
+	})
+	return err
+}
+
+// batch gathers the changes from the backend until they settle. Changes that come in while nobody is receiving
+// are added to the batch that is waiting, so a slow receiver gets everything it missed in one go
+func (w *Watcher) batch() {
+	defer close(w.changes)
+
+	pending := make(map[string]bool)
+	var ready []string
+	var timer <-chan time.Time
+	var out chan []string
+	for {
+		select {
+		case <-w.done:
+			return
+		case path := <-w.raw:
+			pending[path] = true
+			timer = time.After(settle)
+			out = nil
+		case <-timer:
+			timer = nil
+			ready = make([]string, 0, len(pending))
+			for path := range pending {
+				ready = append(ready, path)
+			}
+			slices.Sort(ready)
+			out = w.changes
+		case out <- ready:
+			pending = make(map[string]bool)
+			out = nil
+		}
+	}
+}
+
+// targets turns the paths into the directories to watch, each with the names of the files to report in it or nil
+// to report everything. Directories are added with all their subdirectories
+func targets(paths []string) (map[string]map[string]bool, error) {
+	dirs := make(map[string]map[string]bool)
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		info, err := os.Stat(path)
+		if err == nil && info.IsDir() {
+			err := filepath.WalkDir(path, func(sub string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !d.IsDir() {
+					return nil
+				}
+				if sub != path && ignored(d.Name()) {
+					return filepath.SkipDir
+				}
+				dirs[sub] = nil
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		dir, name := filepath.Dir(path), filepath.Base(path)
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("unable to watch %s, its directory %s doesn't exist", path, dir)
+		}
+		names, ok := dirs[dir]
+		if ok && names == nil {
+			continue
+		}
+		if !ok {
+			names = make(map[string]bool)
+			dirs[dir] = names
+		}
+		names[name] = true
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("nothing to watch")
+	}
+	return dirs, nil
+}
+
+// wanted says if a change to name in dir is reported
+func wanted(dirs map[string]map[string]bool, dir, name string) bool {
+	if ignored(name) {
+		return false
+	}
+	names, ok := dirs[dir]
+	return ok && (names == nil || names[name])
+}
+
+// ignored skips hidden files and editor backups, vim and emacs write them next to the file being edited
+func ignored(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "#") || strings.HasSuffix(name, "~")
+}
@@ -0,0 +1,140 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// next waits for the next batch of changes, failing the test if none comes
+func next(t *testing.T, w *Watcher) []string {
+	t.Helper()
+	select {
+	case changed := <-w.Changes():
+		return changed
+	case <-time.After(5 * time.Second):
+		t.Fatalf("No changes noticed with %s", w.Backend)
+		return nil
+	}
+}
+
+func write(t *testing.T, path string, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// watchers runs a test with every backend available here
+func watchers(t *testing.T, paths []string, test func(t *testing.T, w *Watcher)) {
+	t.Run(Polling, func(t *testing.T) {
+		w, err := NewPolling(paths, 10*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer w.Close()
+		test(t, w)
+	})
+	t.Run("New", func(t *testing.T) {
+		w, err := New(paths, 10*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer w.Close()
+		if runtime.GOOS == "linux" && w.Backend != Inotify {
+			t.Errorf("Expected inotify on Linux, got %s", w.Backend)
+		}
+		test(t, w)
+	})
+}
+
+func TestWatcher_Directory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "interpreter"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	watchers(t, []string{dir}, func(t *testing.T, w *Watcher) {
+		lexer := filepath.Join(dir, "interpreter", "lexer.go")
+		write(t, filepath.Join(dir, ".lexer.go.swp"), "swap "+w.Backend)
+		write(t, lexer, "package interpreter // "+w.Backend)
+
+		if changed := next(t, w); !slices.Equal(changed, []string{lexer}) {
+			t.Errorf("Expected only %s to change, got %v", lexer, changed)
+		}
+	})
+}
+
+func TestWatcher_NewDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	watchers(t, []string{dir}, func(t *testing.T, w *Watcher) {
+		sub := filepath.Join(dir, "Day"+w.Backend)
+		if err := os.Mkdir(sub, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		// give the backend time to start watching the directory before writing in it
+		time.Sleep(3 * settle)
+		solution := filepath.Join(sub, "day1.go")
+		write(t, solution, "package main // "+w.Backend)
+
+		for !slices.Contains(next(t, w), solution) {
+		}
+	})
+}
+
+func TestWatcher_File(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "day5.txt")
+
+	watchers(t, []string{input}, func(t *testing.T, w *Watcher) {
+		// the input doesn't have to exist yet, and the other inputs next to it are left alone
+		write(t, filepath.Join(dir, "day6.txt"), "....#.... "+w.Backend)
+		write(t, input, "47|53 "+w.Backend)
+
+		if changed := next(t, w); !slices.Equal(changed, []string{input}) {
+			t.Errorf("Expected only %s to change, got %v", input, changed)
+		}
+	})
+}
+
+func TestWatcher_Batches(t *testing.T) {
+	dir := t.TempDir()
+	one, two := filepath.Join(dir, "day1.go"), filepath.Join(dir, "day1_test.go")
+
+	watchers(t, []string{dir}, func(t *testing.T, w *Watcher) {
+		write(t, one, "package main // "+w.Backend)
+		write(t, two, "package main // "+w.Backend)
+
+		// nobody is receiving for a while, the changes wait in one batch
+		time.Sleep(3 * settle)
+		if changed := next(t, w); !slices.Equal(changed, []string{one, two}) {
+			t.Errorf("Expected both files in one batch, got %v", changed)
+		}
+	})
+}
+
+func TestWatcher_Close(t *testing.T) {
+	w, err := New([]string{t.TempDir()}, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, ok := <-w.Changes(); ok {
+		t.Errorf("Expected the changes to be closed")
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Expected closing twice to be fine, got %v", err)
+	}
+}
+
+func TestNew_MissingDirectory(t *testing.T) {
+	if _, err := New([]string{filepath.Join(t.TempDir(), "inputs", "day1.txt")}, time.Second); err == nil {
+		t.Errorf("Expected an error for a file in a directory that doesn't exist")
+	}
+}